@@ -0,0 +1,94 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"hhruBot/internal/backup"
+	"hhruBot/internal/storage"
+)
+
+// backupCommand снимает копию всех данных бота: ./hhruBot backup [-o файл]
+func backupCommand(args []string) error {
+	fs := flag.NewFlagSet("backup", flag.ContinueOnError)
+	out := fs.String("o", "", "файл архива (по умолчанию hhrubot-<дата>.jsonl.gz, - — stdout)")
+	cfg, err := loadCommandConfig(fs, args)
+	if err != nil {
+		return err
+	}
+	store := storage.NewStorage(cfg)
+
+	path := *out
+	if path == "" {
+		path = "hhrubot-" + time.Now().Format("2006-01-02-150405") + ".jsonl.gz"
+	}
+	var keys int
+	if path == "-" {
+		keys, err = backup.Write(os.Stdout, store, "redis")
+	} else {
+		keys, err = writeBackupFile(path, store)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "сохранено ключей: %d → %s\n", keys, path)
+	return nil
+}
+
+// writeBackupFile пишет архив в файл; недописанный файл удаляется
+func writeBackupFile(path string, store *storage.Storage) (int, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return 0, err
+	}
+	keys, err := backup.Write(f, store, "redis")
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+	}
+	return keys, err
+}
+
+// restoreCommand загружает копию: ./hhruBot restore [-mode merge|replace] [-dry-run] файл
+func restoreCommand(args []string) error {
+	fs := flag.NewFlagSet("restore", flag.ContinueOnError)
+	mode := fs.String("mode", backup.Merge, "merge — дополнить текущие данные, replace — удалить все ключи базы и загрузить архив")
+	dryRun := fs.Bool("dry-run", false, "только показать, что изменится")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Использование: restore [флаги] <файл архива или - для stdin>")
+		fs.PrintDefaults()
+	}
+	cfg, err := loadCommandConfig(fs, args)
+	if err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errors.New("укажите файл архива")
+	}
+
+	var r io.Reader = os.Stdin
+	if path := fs.Arg(0); path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+	archive, err := backup.Read(r)
+	if err != nil {
+		return err
+	}
+
+	store := storage.NewStorage(cfg)
+	report, err := backup.Restore(archive, store, *mode, *dryRun)
+	fmt.Fprintf(os.Stderr, "архив от %s: %s\n", archive.Header.CreatedAt.Format("02.01.2006 15:04"), report)
+	return err
+}
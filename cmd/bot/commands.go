@@ -0,0 +1,121 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"hhruBot/internal/config"
+	"hhruBot/internal/hh"
+	"hhruBot/internal/logging"
+)
+
+// command — подкоманда: ./hhruBot <команда> [флаги]. Без команды запускается бот.
+type command struct {
+	run     func(args []string) error
+	summary string
+}
+
+var commands map[string]command
+
+func init() {
+	commands = map[string]command{
+		"search":  {searchCommand, "разовый поиск вакансий с фильтрами бота"},
+		"areas":   {areasCommand, "коды регионов hh.ru по названию"},
+		"check":   {checkCommand, "пробный цикл проверки пользователя без отправки"},
+		"users":   {usersCommand, "список пользователей, пауза, отключение и удаление"},
+		"migrate": {migrateCommand, "применить миграции хранилища"},
+		"backup":  {backupCommand, "резервная копия всех данных"},
+		"restore": {restoreCommand, "восстановление из резервной копии"},
+		"help":    {helpCommand, "список подкоманд"},
+	}
+}
+
+// runCommand выполняет подкоманду и возвращает код завершения процесса
+func runCommand(name string, args []string) int {
+	err := commands[name].run(args)
+	if errors.Is(err, flag.ErrHelp) {
+		return 0
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", name, err)
+		return 1
+	}
+	return 0
+}
+
+func helpCommand([]string) error {
+	printCommands(os.Stdout)
+	return nil
+}
+
+func printCommands(w io.Writer) {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(w, "Использование: hhruBot [флаги] — запустить бота, hhruBot <команда> [флаги] — выполнить команду:")
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, name := range names {
+		fmt.Fprintf(tw, "  %s\t%s\n", name, commands[name].summary)
+	}
+	tw.Flush()
+	fmt.Fprintln(w, "Флаги команды: hhruBot <команда> -h")
+}
+
+// loadCommandConfig читает настройки для подкоманды: токен бота не нужен,
+// логи пишутся в stderr и не смешиваются с выводом команды
+func loadCommandConfig(fs *flag.FlagSet, args []string) (*config.Config, error) {
+	cfg, err := config.LoadFlags(fs, args, false)
+	if err != nil {
+		return nil, err
+	}
+	if err := logging.Setup(cfg.LogLevel, cfg.LogFormat); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func newHHClient(cfg *config.Config) *hh.Client {
+	return hh.NewClient(hh.Options{
+		APIRoot:       cfg.HH.BaseURL,
+		UserAgent:     cfg.HH.UserAgent,
+		Timeout:       cfg.HH.Timeout,
+		FallbackText:  cfg.Search.FallbackTag,
+		FallbackAreas: cfg.Search.FallbackAreas,
+	})
+}
+
+// Форматы вывода подкоманд
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+)
+
+func formatFlag(fs *flag.FlagSet) *string {
+	return fs.String("format", formatTable, "формат вывода: table или json")
+}
+
+func checkFormat(format string) error {
+	if format != formatTable && format != formatJSON {
+		return fmt.Errorf("неизвестный формат %q (table или json)", format)
+	}
+	return nil
+}
+
+func printJSON(v any) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func newTable() *tabwriter.Writer {
+	return tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+}
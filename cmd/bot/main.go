@@ -1,31 +1,86 @@
 package main
 
 import (
-	"log"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	_ "time/tzdata" // часовые пояса пользователей в образе без tzdata
 
 	"hhruBot/internal/config"
 	"hhruBot/internal/hh"
+	"hhruBot/internal/logging"
+	"hhruBot/internal/metrics"
+	"hhruBot/internal/server"
 	"hhruBot/internal/storage"
 	"hhruBot/internal/telegram"
 )
 
 func main() {
-	log.Println("⚙️ Загрузка конфигурации...")
-	cfg := config.LoadConfig()
+	if len(os.Args) > 1 && !strings.HasPrefix(os.Args[1], "-") {
+		if _, ok := commands[os.Args[1]]; !ok {
+			fmt.Fprintf(os.Stderr, "неизвестная команда %q\n", os.Args[1])
+			printCommands(os.Stderr)
+			os.Exit(2)
+		}
+		os.Exit(runCommand(os.Args[1], os.Args[2:]))
+	}
+
+	slog.Info("загрузка конфигурации")
+	cfg, err := config.Load(os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		slog.Error("не удалось загрузить конфигурацию", logging.Err(err))
+		os.Exit(1)
+	}
+	if err := logging.Setup(cfg.LogLevel, cfg.LogFormat); err != nil {
+		slog.Error("некорректные настройки логирования", logging.Err(err))
+		os.Exit(1)
+	}
 
-	log.Println("⚙️ Подключение к хранилищу...")
+	slog.Info("подключение к хранилищу")
 	store := storage.NewStorage(cfg)
+	if pending, err := store.PendingMigrations(); err == nil && len(pending) > 0 {
+		slog.Warn("хранилище требует миграции, выполните: hhruBot migrate", "pending", len(pending))
+	}
+
+	hhClient := newHHClient(cfg)
 
-	log.Println("⚙️ Инициализация карты городов...")
-	if err := hh.InitCityMap(); err != nil {
-		log.Fatalf("❌ Не удалось инициализировать карту городов: %v", err)
+	slog.Info("инициализация карты городов")
+	if err := hhClient.InitCityMap(); err != nil {
+		slog.Error("не удалось инициализировать карту городов", logging.Err(err))
+		os.Exit(1)
 	}
 
-	log.Println("⚙️ Создание и запуск Telegram-бота...")
-	bot := telegram.NewBot(cfg, store)
+	slog.Info("создание и запуск Telegram-бота")
+	bot := telegram.NewBot(cfg, store, hhClient)
 
 	go bot.Start()
 
-	log.Println("✅ Бот запущен и готов к работе.")
+	if cfg.HTTPAddr != "" {
+		slog.Info("запуск HTTP-сервера метрик и проверок здоровья")
+		metrics.QueueDepth.Set(func() float64 { return float64(store.PendingTimers()) })
+
+		srv := server.New(cfg.HTTPAddr, cfg.HTTPReadTimeout)
+		srv.Handle("/metrics", metrics.Handler())
+		srv.Handle("GET /feed/{token}/{file}", bot.FeedHandler())
+		srv.Handle("/api/", bot.APIHandler())
+		srv.Handle("/web/", bot.WebHandler())
+		srv.AddReadinessCheck("redis", store.Ping)
+		srv.AddReadinessCheck("city_map", hh.CityMapReady)
+		srv.AddReadinessCheck("telegram", bot.Ready)
+		go func() {
+			if err := srv.Start(); err != nil {
+				slog.Error("HTTP-сервер остановлен", logging.Err(err))
+				os.Exit(1)
+			}
+		}()
+	}
+
+	slog.Info("бот запущен и готов к работе")
 	select {}
 }
@@ -0,0 +1,317 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"hhruBot/internal/hh"
+	"hhruBot/internal/notify"
+	"hhruBot/internal/sources"
+	"hhruBot/internal/storage"
+	"hhruBot/internal/telegram"
+)
+
+// vacancyRow — вакансия в выводе search и check
+type vacancyRow struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Employer    string   `json:"employer"`
+	Area        string   `json:"area"`
+	OtherAreas  []string `json:"other_areas,omitempty"`
+	Salary      string   `json:"salary,omitempty"`
+	Score       *int     `json:"score,omitempty"`
+	Reasons     []string `json:"reasons,omitempty"`
+	Source      string   `json:"source"`
+	PublishedAt string   `json:"published_at,omitempty"`
+	URL         string   `json:"url"`
+}
+
+func newVacancyRow(item notify.Item) vacancyRow {
+	v := item.Vacancy
+	row := vacancyRow{
+		ID:          v.Id,
+		Name:        v.Name,
+		Employer:    v.Employer.Name,
+		Area:        v.Area.Name,
+		OtherAreas:  item.OtherAreas,
+		Salary:      item.Salary,
+		Source:      sources.SourceOf(v),
+		PublishedAt: v.PublishedAt,
+		URL:         item.URL,
+	}
+	if item.WithScore {
+		score := item.Score
+		row.Score, row.Reasons = &score, item.Reasons
+	}
+	return row
+}
+
+func printVacancies(items []notify.Item) {
+	tw := newTable()
+	fmt.Fprintln(tw, "БАЛЛ\tВАКАНСИЯ\tКОМПАНИЯ\tГОРОД\tЗАРПЛАТА\tИСТОЧНИК\tССЫЛКА")
+	for _, item := range items {
+		row := newVacancyRow(item)
+		score := "—"
+		if row.Score != nil {
+			score = strconv.Itoa(*row.Score)
+		}
+		area := row.Area
+		if len(row.OtherAreas) > 0 {
+			area += " +" + strconv.Itoa(len(row.OtherAreas))
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", score, row.Name, row.Employer, area, row.Salary, row.Source, row.URL)
+	}
+	tw.Flush()
+}
+
+// searchCommand — разовый поиск из терминала с теми же источниками, оценкой
+// и склейкой дубликатов, что у бота: ./hhruBot search [флаги] golang,go
+func searchCommand(args []string) error {
+	fs := flag.NewFlagSet("search", flag.ContinueOnError)
+	chat := fs.Int64("chat", 0, "взять теги, города, источники и оценку из настроек пользователя")
+	cities := fs.String("city", "", "города через запятую")
+	srcs := fs.String("sources", "", "источники через запятую: "+strings.Join(sources.Order, ", "))
+	var ps telegram.ProfileSettings
+	fs.StringVar(&ps.Keywords, "keywords", "", "слова с весами, как в /keywords: golang:3,-1с:5")
+	fs.StringVar(&ps.Skills, "skills", "", "навыки с весами, как в /skills")
+	fs.StringVar(&ps.MinSalary, "minsalary", "", "минимальная зарплата")
+	fs.StringVar(&ps.Threshold, "threshold", "", "минимальный балл")
+	fs.StringVar(&ps.Currency, "currency", "", "валюта зарплат: RUR, USD, EUR, ...")
+	fs.StringVar(&ps.SalaryBasis, "basis", "", "зарплата на руки (net) или до вычета (gross)")
+	since := fs.Duration("since", 24*time.Hour, "вакансии, опубликованные за этот период")
+	limit := fs.Int("limit", 50, "сколько вакансий показать")
+	format := formatFlag(fs)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Использование: search [флаги] <теги через запятую>")
+		fs.PrintDefaults()
+	}
+	cfg, err := loadCommandConfig(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := checkFormat(*format); err != nil {
+		return err
+	}
+
+	store := storage.NewStorage(cfg)
+	hhClient := newHHClient(cfg)
+	if err := hhClient.InitCityMap(); err != nil {
+		return err
+	}
+	bot := telegram.NewOffline(cfg, store, hhClient)
+
+	// Настройки пользователя — основа, флаги и аргументы их переопределяют
+	search := storage.SavedSearch{Name: "search"}
+	profile := telegram.ProfileSettings{}
+	if *chat != 0 {
+		search = bot.UserSearches(*chat)[0]
+		profile = bot.UserProfile(*chat)
+	}
+	if tags := strings.Join(fs.Args(), " "); tags != "" {
+		search.Tags = splitList(tags)
+	}
+	if *cities != "" {
+		search.Cities = splitList(*cities)
+	}
+	if *srcs != "" {
+		search.Sources = splitList(*srcs)
+	}
+	if unknown := bot.Sources.Validate(search.Sources); len(unknown) > 0 {
+		return fmt.Errorf("источники не включены в настройках: %s", strings.Join(unknown, ", "))
+	}
+	for _, city := range search.Cities {
+		if hh.CityToAreaID(city) == "" {
+			fmt.Fprintf(os.Stderr, "город %q не найден на hh.ru, ищем без него (подсказка: hhruBot areas %s)\n", city, city)
+		}
+	}
+	overrideProfile(&profile, ps)
+
+	items, found, err := bot.SearchOnce(search, profile, time.Now().Add(-*since))
+	if err != nil && found == 0 {
+		return err
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "часть источников ответила ошибкой: %v\n", err)
+	}
+	total := len(items)
+	if len(items) > *limit {
+		items = items[:*limit]
+	}
+
+	if *format == formatJSON {
+		rows := make([]vacancyRow, len(items))
+		for i, item := range items {
+			rows[i] = newVacancyRow(item)
+		}
+		return printJSON(rows)
+	}
+	printVacancies(items)
+	fmt.Fprintf(os.Stderr, "найдено %d, после фильтров и склейки дубликатов %d, показано %d\n", found, total, len(items))
+	return nil
+}
+
+// overrideProfile заменяет настройки оценки теми, что заданы флагами
+func overrideProfile(p *telegram.ProfileSettings, flags telegram.ProfileSettings) {
+	for _, f := range []struct{ dst, src *string }{
+		{&p.Keywords, &flags.Keywords},
+		{&p.Skills, &flags.Skills},
+		{&p.MinSalary, &flags.MinSalary},
+		{&p.Threshold, &flags.Threshold},
+		{&p.Currency, &flags.Currency},
+		{&p.SalaryBasis, &flags.SalaryBasis},
+	} {
+		if *f.src != "" {
+			*f.dst = *f.src
+		}
+	}
+}
+
+func splitList(s string) []string {
+	var result []string
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			result = append(result, part)
+		}
+	}
+	return result
+}
+
+// areasCommand ищет коды регионов hh.ru: ./hhruBot areas Москва
+func areasCommand(args []string) error {
+	fs := flag.NewFlagSet("areas", flag.ContinueOnError)
+	format := formatFlag(fs)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Использование: areas [флаги] <название города или региона>")
+		fs.PrintDefaults()
+	}
+	cfg, err := loadCommandConfig(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := checkFormat(*format); err != nil {
+		return err
+	}
+	query := strings.Join(fs.Args(), " ")
+	if strings.TrimSpace(query) == "" {
+		fs.Usage()
+		return errors.New("укажите название")
+	}
+
+	areas, err := newHHClient(cfg).GetAreas()
+	if err != nil {
+		return err
+	}
+	matches := hh.FindAreas(areas, query)
+	if *format == formatJSON {
+		return printJSON(matches)
+	}
+	if len(matches) == 0 {
+		return fmt.Errorf("регион %q не найден", query)
+	}
+	tw := newTable()
+	fmt.Fprintln(tw, "ID\tНАЗВАНИЕ\tГДЕ")
+	for _, m := range matches {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", m.ID, m.Name, m.Path)
+	}
+	return tw.Flush()
+}
+
+// checkResult — итог пробной проверки одного поиска в выводе check
+type checkResult struct {
+	SearchID  string       `json:"search_id"`
+	Name      string       `json:"name"`
+	Tags      []string     `json:"tags"`
+	Cities    []string     `json:"cities"`
+	Sources   []string     `json:"sources"`
+	Found     int          `json:"found"`
+	Seen      int          `json:"already_sent"`
+	Reposts   int          `json:"reposts"`
+	Vacancies []vacancyRow `json:"vacancies"`
+	Error     string       `json:"error,omitempty"`
+}
+
+// checkCommand выполняет один цикл чекера пользователя в пробном режиме:
+// ./hhruBot check -chat 123456789
+func checkCommand(args []string) error {
+	fs := flag.NewFlagSet("check", flag.ContinueOnError)
+	chat := fs.Int64("chat", 0, "chatID пользователя")
+	since := fs.Duration("since", 0, "искать за этот период (по умолчанию — с последней проверки, как чекер)")
+	format := formatFlag(fs)
+	cfg, err := loadCommandConfig(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := checkFormat(*format); err != nil {
+		return err
+	}
+	if *chat == 0 {
+		fs.Usage()
+		return errors.New("укажите -chat")
+	}
+
+	store := storage.NewStorage(cfg)
+	hhClient := newHHClient(cfg)
+	if err := hhClient.InitCityMap(); err != nil {
+		return err
+	}
+	bot := telegram.NewOffline(cfg, store, hhClient)
+
+	from := bot.CheckFrom(*chat)
+	if *since > 0 {
+		from = time.Now().Add(-*since)
+	}
+	results := bot.DryRunCheck(*chat, from)
+
+	out := make([]checkResult, len(results))
+	for i, r := range results {
+		out[i] = checkResult{
+			SearchID: r.Search.Id,
+			Name:     r.Search.Name,
+			Tags:     r.Search.Tags,
+			Cities:   r.Search.Cities,
+			Sources:  r.Search.Sources,
+			Found:    r.Found,
+			Seen:     r.Seen,
+			Reposts:  r.Reposts,
+		}
+		for _, item := range r.Items {
+			out[i].Vacancies = append(out[i].Vacancies, newVacancyRow(item))
+		}
+		if r.Err != nil {
+			out[i].Error = r.Err.Error()
+		}
+	}
+	if *format == formatJSON {
+		return printJSON(out)
+	}
+
+	paused, _ := store.IsUserPaused(*chat)
+	disabled, _ := store.IsUserDisabled(*chat)
+	fmt.Printf("Пользователь %d, вакансии с %s, на паузе: %t, отключён: %t\n", *chat, from.Format("02.01.2006 15:04"), paused, disabled)
+	if store.IsMaintenance() {
+		fmt.Println("⚠ Включён режим обслуживания: настоящий чекер сейчас ничего не проверяет")
+	}
+	for i, r := range results {
+		fmt.Printf("\n🔎 %s [%s]: теги %s, города %s\n", r.Search.Name, r.Search.Id, orDash(r.Search.Tags), orDash(r.Search.Cities))
+		fmt.Printf("найдено %d, уже отправлены %d, перепубликации %d, ушло бы пользователю %d\n", r.Found, r.Seen, r.Reposts, len(r.Items))
+		if r.Err != nil {
+			fmt.Println("ошибка:", out[i].Error)
+		}
+		if len(r.Items) > 0 {
+			printVacancies(r.Items)
+		}
+	}
+	return nil
+}
+
+func orDash(values []string) string {
+	if len(values) == 0 {
+		return "—"
+	}
+	return strings.Join(values, ", ")
+}
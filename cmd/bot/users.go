@@ -0,0 +1,205 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"hhruBot/internal/storage"
+)
+
+// userRow — пользователь в выводе users
+type userRow struct {
+	ChatID      int64  `json:"chat_id"`
+	Paused      bool   `json:"paused"`
+	Disabled    bool   `json:"disabled"`
+	Tags        string `json:"tags"`
+	Cities      string `json:"cities"`
+	Interval    string `json:"interval"`
+	LastChecked string `json:"last_checked,omitempty"`
+	Searches    int    `json:"saved_searches"`
+	Saved       int    `json:"saved_vacancies"`
+	Tracked     int    `json:"tracked_vacancies"`
+	Employers   int    `json:"employers"`
+}
+
+func loadUserRow(store *storage.Storage, chatID int64) userRow {
+	setting := func(key string) string {
+		val, _ := store.GetUserSetting(chatID, key)
+		return val
+	}
+	row := userRow{ChatID: chatID, Tags: setting("tags"), Cities: setting("cities"), Interval: setting("interval")}
+	row.Paused, _ = store.IsUserPaused(chatID)
+	row.Disabled, _ = store.IsUserDisabled(chatID)
+	if t, err := store.GetLastChecked(chatID); err == nil {
+		row.LastChecked = t.Format("2006-01-02 15:04")
+	}
+	searches, _ := store.GetSavedSearches(chatID)
+	saved, _ := store.GetSavedVacancies(chatID)
+	tracked, _ := store.GetTrackedIDs(chatID)
+	employers, _ := store.GetWatchedEmployers(chatID)
+	row.Searches, row.Saved, row.Tracked, row.Employers = len(searches), len(saved), len(tracked), len(employers)
+	return row
+}
+
+func (r userRow) status() string {
+	switch {
+	case r.Disabled:
+		return "отключён"
+	case r.Paused:
+		return "пауза"
+	}
+	return "активен"
+}
+
+// usersCommand — пользователи бота: ./hhruBot users [list|show|pause|resume|disable|enable|delete] [id]
+func usersCommand(args []string) error {
+	action := "list"
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		action, args = args[0], args[1:]
+	}
+	fs := flag.NewFlagSet("users "+action, flag.ContinueOnError)
+	format := formatFlag(fs)
+	yes := fs.Bool("yes", false, "подтвердить удаление (delete)")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), `Использование:
+  users [list]             все пользователи
+  users show <chatID>      настройки и счётчики пользователя
+  users pause <chatID>     приостановить проверки, resume — возобновить
+  users disable <chatID>   отключить (как после блокировки бота), enable — включить
+  users delete <chatID> -yes  удалить все данные пользователя`)
+		fs.PrintDefaults()
+	}
+	cfg, err := loadCommandConfig(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := checkFormat(*format); err != nil {
+		return err
+	}
+
+	if action == "list" {
+		store := storage.NewStorage(cfg)
+		return listUsers(store, *format)
+	}
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errors.New("укажите chatID пользователя")
+	}
+	chatID, err := strconv.ParseInt(fs.Arg(0), 10, 64)
+	if err != nil {
+		return fmt.Errorf("некорректный chatID %q", fs.Arg(0))
+	}
+
+	var change func(*storage.Storage, int64) error
+	var done string
+	switch action {
+	case "show":
+		store := storage.NewStorage(cfg)
+		row := loadUserRow(store, chatID)
+		if *format == formatJSON {
+			return printJSON(row)
+		}
+		fmt.Printf("Пользователь %d: %s\nТеги: %s\nГорода: %s\nИнтервал: %s\nПоследняя проверка: %s\nПоисков: %d, закладок: %d, отслеживает вакансий: %d, работодателей: %d\n",
+			row.ChatID, row.status(), orDash(splitList(row.Tags)), orDash(splitList(row.Cities)), orDashString(row.Interval), orDashString(row.LastChecked),
+			row.Searches, row.Saved, row.Tracked, row.Employers)
+		return nil
+	case "pause":
+		change, done = (*storage.Storage).PauseUser, "поставлен на паузу"
+	case "resume":
+		change, done = (*storage.Storage).ResumeUser, "снят с паузы"
+	case "disable":
+		change, done = (*storage.Storage).DisableUser, "отключён"
+	case "enable":
+		change, done = (*storage.Storage).EnableUser, "включён"
+	case "delete":
+		if !*yes {
+			return errors.New("удаление необратимо: повторите с флагом -yes")
+		}
+		change, done = (*storage.Storage).DeleteUser, "удалён со всеми данными. Если бот запущен, перезапустите его, чтобы остановить чекер пользователя, — или удаляйте на работающем боте командой /user <chatID> delete"
+	default:
+		fs.Usage()
+		return fmt.Errorf("неизвестное действие %q", action)
+	}
+
+	store := storage.NewStorage(cfg)
+	if err := change(store, chatID); err != nil {
+		return err
+	}
+	fmt.Printf("Пользователь %d %s\n", chatID, done)
+	return nil
+}
+
+func listUsers(store *storage.Storage, format string) error {
+	users, err := store.GetUsers()
+	if err != nil {
+		return err
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i] < users[j] })
+
+	rows := make([]userRow, len(users))
+	for i, chatID := range users {
+		rows[i] = loadUserRow(store, chatID)
+	}
+	if format == formatJSON {
+		return printJSON(rows)
+	}
+	tw := newTable()
+	fmt.Fprintln(tw, "CHAT ID\tСТАТУС\tТЕГИ\tГОРОДА\tИНТЕРВАЛ\tПРОВЕРКА\tПОИСКИ\tЗАКЛАДКИ")
+	for _, r := range rows {
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%s\t%d\t%d\n", r.ChatID, r.status(),
+			orDashString(r.Tags), orDashString(r.Cities), orDashString(r.Interval), orDashString(r.LastChecked), r.Searches, r.Saved)
+	}
+	return tw.Flush()
+}
+
+func orDashString(s string) string {
+	if s == "" {
+		return "—"
+	}
+	return s
+}
+
+// migrateCommand применяет миграции хранилища: ./hhruBot migrate [-dry-run]
+func migrateCommand(args []string) error {
+	fs := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	dryRun := fs.Bool("dry-run", false, "только показать, какие миграции будут применены")
+	cfg, err := loadCommandConfig(fs, args)
+	if err != nil {
+		return err
+	}
+	store := storage.NewStorage(cfg)
+
+	version, err := store.SchemaVersion()
+	if err != nil {
+		return err
+	}
+	pending, err := store.PendingMigrations()
+	if err != nil {
+		return err
+	}
+	if len(pending) == 0 {
+		fmt.Printf("Хранилище актуально, версия схемы %d\n", version)
+		return nil
+	}
+	if *dryRun {
+		fmt.Printf("Версия схемы %d, будут применены:\n", version)
+		for _, m := range pending {
+			fmt.Printf("  %d. %s\n", m.Version, m.Name)
+		}
+		return nil
+	}
+
+	err = store.Migrate(func(m storage.Migration) {
+		fmt.Printf("→ %d. %s\n", m.Version, m.Name)
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Готово, версия схемы %d\n", pending[len(pending)-1].Version)
+	return nil
+}
@@ -0,0 +1,212 @@
+// Резервная копия данных бота: переносимый архив и восстановление из него
+package backup
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"hhruBot/internal/storage"
+)
+
+// Архив — gzip с JSON Lines: первая строка — Header, затем по строке на ключ
+// (storage.Entry) и последняя — footer с числом ключей, по которому видно,
+// что архив не обрезан.
+const (
+	Format  = "hhrubot-backup"
+	Version = 1
+)
+
+// Header — первая строка архива
+type Header struct {
+	Format    string    `json:"format"`
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	Backend   string    `json:"backend"`
+}
+
+type footer struct {
+	End  bool `json:"end"`
+	Keys int  `json:"keys"`
+}
+
+// line — строка архива после заголовка: ключ или footer
+type line struct {
+	storage.Entry
+	footer
+}
+
+// Source — хранилище, из которого снимается копия
+type Source interface {
+	Entries(fn func(storage.Entry) error) error
+}
+
+// Target — хранилище, в которое восстанавливается копия
+type Target interface {
+	KeyExists(key string) (bool, error)
+	CountKeys() (int64, error)
+	Flush() error
+	PutEntry(e storage.Entry, merge bool) error
+}
+
+// Write снимает копию всех ключей src в w и возвращает их число
+func Write(w io.Writer, src Source, backend string) (int, error) {
+	zw := gzip.NewWriter(w)
+	enc := json.NewEncoder(zw)
+	if err := enc.Encode(Header{Format: Format, Version: Version, CreatedAt: time.Now(), Backend: backend}); err != nil {
+		return 0, err
+	}
+	var keys int
+	err := src.Entries(func(e storage.Entry) error {
+		keys++
+		return enc.Encode(e)
+	})
+	if err != nil {
+		return keys, err
+	}
+	if err := enc.Encode(footer{End: true, Keys: keys}); err != nil {
+		return keys, err
+	}
+	return keys, zw.Close()
+}
+
+// Archive — прочитанный и проверенный архив
+type Archive struct {
+	Header  Header
+	Entries []storage.Entry
+}
+
+// Read читает архив целиком. Ошибка — если формат чужой, версия новее
+// поддерживаемой или архив обрезан.
+func Read(r io.Reader) (*Archive, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("не архив резервной копии: %w", err)
+	}
+	defer zr.Close()
+
+	scanner := bufio.NewScanner(zr)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	if !scanner.Scan() {
+		return nil, errors.New("архив пуст")
+	}
+	var a Archive
+	if err := json.Unmarshal(scanner.Bytes(), &a.Header); err != nil || a.Header.Format != Format {
+		return nil, errors.New("не архив резервной копии hhrubot")
+	}
+	if a.Header.Version > Version {
+		return nil, fmt.Errorf("архив версии %d создан более новой версией бота, поддерживается до %d", a.Header.Version, Version)
+	}
+
+	for n := 2; scanner.Scan(); n++ {
+		var l line
+		if err := json.Unmarshal(scanner.Bytes(), &l); err != nil {
+			return nil, fmt.Errorf("строка %d: %w", n, err)
+		}
+		if l.End {
+			if l.Keys != len(a.Entries) {
+				return nil, fmt.Errorf("в архиве %d ключей, а ожидалось %d", len(a.Entries), l.Keys)
+			}
+			return &a, nil
+		}
+		if l.Key == "" {
+			return nil, fmt.Errorf("строка %d: пустой ключ", n)
+		}
+		a.Entries = append(a.Entries, l.Entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, errors.New("архив обрезан: нет завершающей строки")
+}
+
+// Режимы восстановления
+const (
+	// Merge — дополнить текущие данные: существующие ключи не перезаписываются,
+	// в множества и хеши добавляются недостающие элементы
+	Merge = "merge"
+	// Replace — удалить все ключи базы и загрузить архив
+	Replace = "replace"
+)
+
+// Report — что сделано (или будет сделано при DryRun) при восстановлении
+type Report struct {
+	Mode    string
+	DryRun  bool
+	Keys    int   // ключей в архиве
+	Created int   // новых ключей
+	Merged  int   // существующих множеств и хешей, дополненных из архива
+	Skipped int   // существующих строк и списков, оставленных как есть
+	Expired int   // ключей, срок жизни которых истёк после снятия копии
+	Deleted int64 // ключей, удалённых перед восстановлением (Replace)
+}
+
+// Restore загружает архив в dst. С dryRun ничего не меняет и только считает.
+func Restore(a *Archive, dst Target, mode string, dryRun bool) (Report, error) {
+	if mode != Merge && mode != Replace {
+		return Report{}, fmt.Errorf("неизвестный режим %q (%s или %s)", mode, Merge, Replace)
+	}
+	r := Report{Mode: mode, DryRun: dryRun, Keys: len(a.Entries)}
+	merge := mode == Merge
+
+	if !merge {
+		n, err := dst.CountKeys()
+		if err != nil {
+			return r, err
+		}
+		r.Deleted = n
+		if !dryRun {
+			if err := dst.Flush(); err != nil {
+				return r, err
+			}
+		}
+	}
+
+	now := time.Now()
+	for _, e := range a.Entries {
+		if e.ExpireAt != nil && !e.ExpireAt.After(now) {
+			r.Expired++
+			continue
+		}
+
+		exists := false
+		if merge {
+			var err error
+			if exists, err = dst.KeyExists(e.Key); err != nil {
+				return r, err
+			}
+		}
+		switch {
+		case !exists:
+			r.Created++
+		case e.Mergeable():
+			r.Merged++
+		default:
+			r.Skipped++
+			continue
+		}
+
+		if !dryRun {
+			if err := dst.PutEntry(e, merge); err != nil {
+				return r, err
+			}
+		}
+	}
+	return r, nil
+}
+
+func (r Report) String() string {
+	s := fmt.Sprintf("режим %s: ключей в архиве %d, новых %d, дополнено %d, оставлено как есть %d, истекло %d",
+		r.Mode, r.Keys, r.Created, r.Merged, r.Skipped, r.Expired)
+	if r.Mode == Replace {
+		s += fmt.Sprintf(", удалено прежних %d", r.Deleted)
+	}
+	if r.DryRun {
+		s = "пробный запуск, данные не изменены — " + s
+	}
+	return s
+}
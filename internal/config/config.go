@@ -2,9 +2,17 @@
 package config
 
 import (
-	"github.com/joho/godotenv"
-	"log"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net/url"
 	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
 )
 
 type Config struct {
@@ -13,25 +21,262 @@ type Config struct {
 	RedisAddr        string
 	RedisPassword    string
 	RedisDB          int
+	AdminIDs         []int64
+	HTTPAddr         string
+	HTTPReadTimeout  time.Duration
+	PublicURL        string // внешний адрес HTTP-сервера для ссылок на ленты
+	LogLevel         string
+	LogFormat        string
+	HH               HH
+	Search           Search
+	Sources          Sources
+	Notify           Notify
 }
 
-func LoadConfig() *Config {
-	err := godotenv.Load()
-	if err != nil {
-		log.Println(".env Файл не найден")
-	}
+// HH — подключение к API hh.ru
+type HH struct {
+	BaseURL   string
+	UserAgent string
+	Timeout   time.Duration
+}
+
+// Search — параметры фоновой проверки вакансий
+type Search struct {
+	DefaultInterval  time.Duration
+	MinInterval      time.Duration
+	FallbackAreas    []string // регионы, если пользователь не указал города (1 — Москва, 2 — Санкт-Петербург)
+	FallbackTag      string   // запрос, если пользователь не указал теги
+	SeenTTL          time.Duration
+	HistorySize      int           // сколько последних совпадений хранить для ленты поиска
+	HistoryRetention time.Duration // сколько хранить историю отправленных вакансий (/history, /export)
+}
+
+// Sources — дополнительные источники вакансий помимо hh.ru
+type Sources struct {
+	SuperJobKey string   // ключ приложения api.superjob.ru; пусто — источник выключен
+	Trudvsem    bool     // открытый API «Работа России»
+	RSSFeeds    []string // ленты вакансий RSS/Atom; {query} заменяется на запрос
+}
 
-	botToken := os.Getenv("TELEGRAM_BOT_TOKEN")
-	chatID := os.Getenv("TELEGRAM_CHAT_ID")
-	redisAddr := os.Getenv("REDIS_ADDR")
-	redisPassword := os.Getenv("REDIS_PASSWORD")
-	redisDB := 0
+// Notify — серверы для каналов доставки: почта и Matrix
+type Notify struct {
+	SMTPHost         string // пусто — доставка на почту выключена
+	SMTPPort         int
+	SMTPUsername     string
+	SMTPPassword     string
+	SMTPFrom         string
+	MatrixHomeserver string // пусто — Matrix выключен
+	MatrixToken      string // токен доступа аккаунта бота в Matrix
+}
 
+// Default возвращает конфигурацию со значениями по умолчанию
+func Default() *Config {
 	return &Config{
-		TelegramBotToken: botToken,
-		TelegramChatId:   chatID,
-		RedisAddr:        redisAddr,
-		RedisPassword:    redisPassword,
-		RedisDB:          redisDB,
+		RedisAddr:       "localhost:6379",
+		HTTPReadTimeout: 10 * time.Second,
+		LogLevel:        "info",
+		LogFormat:       "text",
+		HH: HH{
+			BaseURL:   "https://api.hh.ru",
+			UserAgent: "golang-job-bot/1.0",
+			Timeout:   10 * time.Second,
+		},
+		Search: Search{
+			DefaultInterval:  30 * time.Minute,
+			MinInterval:      5 * time.Minute,
+			FallbackAreas:    []string{"1", "2"},
+			FallbackTag:      "golang",
+			SeenTTL:          7 * 24 * time.Hour,
+			HistorySize:      100,
+			HistoryRetention: 90 * 24 * time.Hour,
+		},
+		Sources: Sources{
+			Trudvsem: true,
+		},
+		Notify: Notify{
+			SMTPPort: 587,
+		},
+	}
+}
+
+// Load собирает конфигурацию. Приоритет (от низшего к высшему): значения
+// по умолчанию, файл (-config или CONFIG_FILE), переменные окружения и .env, флаги.
+func Load(args []string) (*Config, error) {
+	return LoadFlags(flag.NewFlagSet("hhruBot", flag.ContinueOnError), args, true)
+}
+
+// LoadFlags — Load для подкоманд: к флагам подкоманды в fs добавляются флаги
+// настроек, оставшиеся аргументы доступны через fs.Args(). Подкомандам, которые
+// не ходят в Telegram, токен бота не нужен — для них withTelegram = false.
+func LoadFlags(fs *flag.FlagSet, args []string, withTelegram bool) (*Config, error) {
+	if err := godotenv.Load(); err != nil {
+		slog.Debug(".env файл не найден")
+	}
+
+	configFile := fs.String("config", os.Getenv("CONFIG_FILE"), "путь к файлу настроек (TOML)")
+	flagValues := make(map[string]string)
+	for _, s := range settings {
+		s := s
+		fs.Func(s.flagName(), s.usage, func(val string) error {
+			flagValues[s.key] = val
+			return nil
+		})
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	cfg := Default()
+
+	if *configFile != "" {
+		fileValues, err := parseFile(*configFile)
+		if err != nil {
+			return nil, err
+		}
+		if err := cfg.apply(fileValues, "файл "+*configFile); err != nil {
+			return nil, err
+		}
+	}
+
+	envValues := make(map[string]string)
+	for _, s := range settings {
+		for _, name := range s.env {
+			if val, ok := os.LookupEnv(name); ok && val != "" {
+				envValues[s.key] = val
+				break
+			}
+		}
+	}
+	if err := cfg.apply(envValues, "переменная окружения"); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.apply(flagValues, "флаг"); err != nil {
+		return nil, err
+	}
+
+	// TELEGRAM_CHAT_ID исторически тоже считается администратором
+	if id, err := strconv.ParseInt(cfg.TelegramChatId, 10, 64); err == nil {
+		cfg.AdminIDs = append(cfg.AdminIDs, id)
+	}
+
+	if err := cfg.validate(withTelegram); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func (c *Config) apply(values map[string]string, source string) error {
+	for _, s := range settings {
+		val, ok := values[s.key]
+		if !ok {
+			continue
+		}
+		if err := s.set(c, val); err != nil {
+			return fmt.Errorf("%s, %s: %w", source, s.key, err)
+		}
+	}
+	return nil
+}
+
+// validate проверяет конфигурацию при старте, чтобы не падать позже на первом запросе.
+// Без withTelegram токен бота не обязателен: он не нужен консольным командам.
+func (c *Config) validate(withTelegram bool) error {
+	var errs []error
+	if withTelegram && c.TelegramBotToken == "" {
+		errs = append(errs, errors.New("не задан токен бота (TELEGRAM_BOT_TOKEN или telegram.token)"))
+	}
+	if c.RedisAddr == "" {
+		errs = append(errs, errors.New("не задан адрес Redis (redis.addr)"))
+	}
+	if c.RedisDB < 0 {
+		errs = append(errs, errors.New("redis.db не может быть отрицательным"))
+	}
+	if c.HTTPReadTimeout <= 0 {
+		errs = append(errs, errors.New("http.read_timeout должен быть положительным"))
+	}
+	if c.PublicURL != "" {
+		if u, err := url.Parse(c.PublicURL); err != nil || u.Scheme == "" || u.Host == "" {
+			errs = append(errs, fmt.Errorf("http.public_url: некорректный адрес %q", c.PublicURL))
+		}
+	}
+	if u, err := url.Parse(c.HH.BaseURL); err != nil || u.Scheme == "" || u.Host == "" {
+		errs = append(errs, fmt.Errorf("hh.base_url: некорректный адрес %q", c.HH.BaseURL))
+	}
+	if c.HH.UserAgent == "" {
+		errs = append(errs, errors.New("hh.user_agent не может быть пустым"))
+	}
+	if c.HH.Timeout <= 0 {
+		errs = append(errs, errors.New("hh.timeout должен быть положительным"))
+	}
+	if c.Search.MinInterval < time.Minute {
+		errs = append(errs, errors.New("search.min_interval должен быть не меньше минуты"))
+	}
+	if c.Search.DefaultInterval < c.Search.MinInterval {
+		errs = append(errs, errors.New("search.default_interval меньше search.min_interval"))
+	}
+	if len(c.Search.FallbackAreas) == 0 {
+		errs = append(errs, errors.New("search.fallback_areas не может быть пустым"))
+	}
+	if c.Search.FallbackTag == "" {
+		errs = append(errs, errors.New("search.fallback_tag не может быть пустым"))
+	}
+	if c.Search.SeenTTL <= 0 {
+		errs = append(errs, errors.New("search.seen_ttl должен быть положительным"))
+	}
+	if c.Search.HistorySize <= 0 {
+		errs = append(errs, errors.New("search.history_size должен быть положительным"))
+	}
+	if c.Search.HistoryRetention <= 0 {
+		errs = append(errs, errors.New("search.history_retention должен быть положительным"))
+	}
+	for _, feed := range c.Sources.RSSFeeds {
+		if u, err := url.Parse(feed); err != nil || u.Scheme == "" || u.Host == "" {
+			errs = append(errs, fmt.Errorf("sources.rss_feeds: некорректный адрес %q", feed))
+		}
+	}
+	if c.Notify.SMTPHost != "" && c.Notify.SMTPFrom == "" {
+		errs = append(errs, errors.New("notify.smtp_from: укажите адрес отправителя писем"))
+	}
+	if c.Notify.MatrixHomeserver != "" && c.Notify.MatrixToken == "" {
+		errs = append(errs, errors.New("notify.matrix_token: нужен токен аккаунта бота в Matrix"))
+	}
+	switch strings.ToLower(c.LogLevel) {
+	case "debug", "info", "warn", "error":
+	default:
+		errs = append(errs, fmt.Errorf("log.level: неизвестный уровень %q", c.LogLevel))
+	}
+	switch strings.ToLower(c.LogFormat) {
+	case "text", "json":
+	default:
+		errs = append(errs, fmt.Errorf("log.format: неизвестный формат %q (text или json)", c.LogFormat))
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("некорректная конфигурация: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+func parseIDs(input string) ([]int64, error) {
+	var ids []int64
+	for _, s := range splitList(input) {
+		id, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("некорректный ID %q", s)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// splitList разбирает список через запятую, пропуская пустые элементы
+func splitList(input string) []string {
+	var items []string
+	for _, s := range strings.Split(input, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			items = append(items, s)
+		}
 	}
+	return items
 }
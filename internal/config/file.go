@@ -0,0 +1,218 @@
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// parseFile читает файл настроек в подмножестве TOML: секции [имя], строки,
+// числа, логические значения и одномерные массивы, в том числе на нескольких
+// строках. Комментарии начинаются с #. Вложенные массивы, таблицы в фигурных
+// скобках и многострочные строки не поддерживаются и дают ошибку.
+// Результат — значения по ключам "секция.имя"; массивы склеиваются через запятую.
+func parseFile(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось открыть файл настроек: %w", err)
+	}
+	defer f.Close()
+
+	values := make(map[string]string)
+	section := ""
+	scanner := bufio.NewScanner(f)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(stripComment(scanner.Text()))
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("%s:%d: незакрытая секция", path, lineNo)
+			}
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			continue
+		}
+
+		key, raw, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("%s:%d: ожидается ключ = значение", path, lineNo)
+		}
+		key = strings.TrimSpace(key)
+		if section != "" {
+			key = section + "." + key
+		}
+
+		// массив может продолжаться на следующих строках до закрывающей скобки
+		raw = strings.TrimSpace(raw)
+		start := lineNo
+		for strings.HasPrefix(raw, "[") && arrayEnd(raw) < 0 {
+			if !scanner.Scan() {
+				return nil, fmt.Errorf("%s:%d: незакрытый массив", path, start)
+			}
+			lineNo++
+			raw += " " + strings.TrimSpace(stripComment(scanner.Text()))
+		}
+
+		val, err := parseValue(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, lineNo, err)
+		}
+		if !knownKey(key) {
+			return nil, fmt.Errorf("%s:%d: неизвестный параметр %q", path, lineNo, key)
+		}
+		values[key] = val
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+func parseValue(raw string) (string, error) {
+	switch {
+	case raw == "":
+		return "", fmt.Errorf("пустое значение")
+	case strings.HasPrefix(raw, "["):
+		end := arrayEnd(raw)
+		if end < 0 {
+			return "", fmt.Errorf("незакрытый массив")
+		}
+		if strings.TrimSpace(raw[end+1:]) != "" {
+			return "", fmt.Errorf("лишний текст после массива")
+		}
+		elems, err := splitArray(raw[1:end])
+		if err != nil {
+			return "", err
+		}
+		var items []string
+		for _, elem := range elems {
+			val, err := parseValue(elem)
+			if err != nil {
+				return "", err
+			}
+			// массивы хранятся через запятую, поэтому запятая внутри элемента его разрезала бы
+			if strings.Contains(val, ",") {
+				return "", fmt.Errorf("элемент массива %q содержит запятую", val)
+			}
+			items = append(items, val)
+		}
+		return strings.Join(items, ","), nil
+	case strings.HasPrefix(raw, "{"):
+		return "", fmt.Errorf("таблицы в фигурных скобках не поддерживаются")
+	case strings.HasPrefix(raw, `"""`) || strings.HasPrefix(raw, "'''"):
+		return "", fmt.Errorf("многострочные строки не поддерживаются")
+	case strings.HasPrefix(raw, `"`):
+		return strconv.Unquote(raw)
+	case strings.HasPrefix(raw, "'"):
+		if len(raw) < 2 || !strings.HasSuffix(raw, "'") {
+			return "", fmt.Errorf("незакрытая строка")
+		}
+		return raw[1 : len(raw)-1], nil
+	default:
+		// числа и true/false берём как есть
+		return raw, nil
+	}
+}
+
+// stripComment отрезает комментарий, не трогая # внутри строк
+func stripComment(line string) string {
+	var quote rune
+	escaped := false
+	for i, r := range line {
+		switch {
+		case escaped:
+			escaped = false
+		case quote == '"' && r == '\\':
+			escaped = true
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote == 0 && (r == '"' || r == '\''):
+			quote = r
+		case quote == 0 && r == '#':
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// arrayEnd возвращает индекс скобки, закрывающей массив в начале raw, или -1,
+// если массив не закрыт. Скобки внутри строк не считаются.
+func arrayEnd(raw string) int {
+	var quote rune
+	escaped := false
+	depth := 0
+	for i, r := range raw {
+		switch {
+		case escaped:
+			escaped = false
+		case quote == '"' && r == '\\':
+			escaped = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '[':
+			depth++
+		case r == ']':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// splitArray делит содержимое массива на элементы по запятым вне строк.
+// Запятая после последнего элемента допустима, как в TOML.
+func splitArray(body string) ([]string, error) {
+	var items []string
+	var quote rune
+	escaped := false
+	start := 0
+	for i, r := range body {
+		switch {
+		case escaped:
+			escaped = false
+		case quote == '"' && r == '\\':
+			escaped = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '[' || r == '{':
+			return nil, fmt.Errorf("вложенные массивы и таблицы не поддерживаются")
+		case r == ',':
+			item := strings.TrimSpace(body[start:i])
+			if item == "" {
+				return nil, fmt.Errorf("пустой элемент массива")
+			}
+			items = append(items, item)
+			start = i + 1
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("незакрытая строка")
+	}
+	if item := strings.TrimSpace(body[start:]); item != "" {
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func knownKey(key string) bool {
+	for _, s := range settings {
+		if s.key == key {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,183 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// writeConfig кладёт содержимое во временный config.toml и возвращает путь
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestParseFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    map[string]string
+	}{
+		{
+			name:    "решётка в двойных кавычках",
+			content: "[search]\nfallback_tag = \"c# developer\" # комментарий\n",
+			want:    map[string]string{"search.fallback_tag": "c# developer"},
+		},
+		{
+			name:    "решётка в одинарных кавычках",
+			content: "[search]\nfallback_tag = 'c# #dev' # комментарий\n",
+			want:    map[string]string{"search.fallback_tag": "c# #dev"},
+		},
+		{
+			name:    "экранированные кавычки",
+			content: "[hh]\nuser_agent = \"bot \\\"hh\\\" # 1\" # комментарий\n",
+			want:    map[string]string{"hh.user_agent": `bot "hh" # 1`},
+		},
+		{
+			name:    "запятая после последнего элемента",
+			content: "[search]\nfallback_areas = [\"1\", \"2\",]\n",
+			want:    map[string]string{"search.fallback_areas": "1,2"},
+		},
+		{
+			name: "многострочный массив с комментариями",
+			content: "[search]\nfallback_areas = [\n" +
+				"  \"1\", # Москва\n" +
+				"  \"2\", # Санкт-Петербург\n" +
+				"]\n" +
+				"fallback_tag = \"golang\"\n",
+			want: map[string]string{"search.fallback_areas": "1,2", "search.fallback_tag": "golang"},
+		},
+		{
+			name:    "скобка внутри строки массива",
+			content: "[sources]\nrss_feeds = [\n  \"https://example.com/rss?q=[{query}]\"\n]\n",
+			want:    map[string]string{"sources.rss_feeds": "https://example.com/rss?q=[{query}]"},
+		},
+		{
+			name:    "числа и логические значения",
+			content: "# настройки\n\n[redis]\ndb = 3\n[sources]\ntrudvsem = false\n",
+			want:    map[string]string{"redis.db": "3", "sources.trudvsem": "false"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseFile(writeConfig(t, tt.content))
+			if err != nil {
+				t.Fatalf("ошибка: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("получили %v, ждали %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFileErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		line    string // ожидаемая позиция ошибки ":строка:"
+		message string
+	}{
+		{
+			name:    "неизвестный ключ",
+			content: "[search]\nfallback_tag = \"go\"\nfallback_tags = \"go\"\n",
+			line:    ":3:",
+			message: `неизвестный параметр "search.fallback_tags"`,
+		},
+		{
+			name:    "неизвестная секция",
+			content: "[serach]\nfallback_tag = \"go\"\n",
+			line:    ":2:",
+			message: "неизвестный параметр",
+		},
+		{
+			name:    "строка без знака равенства",
+			content: "[search]\n\nfallback_tag \"go\"\n",
+			line:    ":3:",
+			message: "ожидается ключ = значение",
+		},
+		{
+			name:    "незакрытая секция",
+			content: "# настройки\n[search\n",
+			line:    ":2:",
+			message: "незакрытая секция",
+		},
+		{
+			name:    "незакрытый массив",
+			content: "[search]\nfallback_areas = [\n  \"1\",\n  \"2\",\n",
+			line:    ":2:",
+			message: "незакрытый массив",
+		},
+		{
+			name:    "ошибка в конце многострочного массива",
+			content: "[search]\nfallback_areas = [\n  \"1\",\n] лишнее\n",
+			line:    ":4:",
+			message: "лишний текст после массива",
+		},
+		{
+			name:    "пустой элемент массива",
+			content: "[search]\nfallback_areas = [\"1\",, \"2\"]\n",
+			line:    ":2:",
+			message: "пустой элемент массива",
+		},
+		{
+			name:    "вложенный массив",
+			content: "[search]\nfallback_areas = [[\"1\"], [\"2\"]]\n",
+			line:    ":2:",
+			message: "вложенные массивы",
+		},
+		{
+			name:    "запятая внутри элемента",
+			content: "[search]\nfallback_areas = [\"1,2\"]\n",
+			line:    ":2:",
+			message: "содержит запятую",
+		},
+		{
+			name:    "таблица в фигурных скобках",
+			content: "[redis]\naddr = { host = \"localhost\" }\n",
+			line:    ":2:",
+			message: "таблицы в фигурных скобках",
+		},
+		{
+			name:    "многострочная строка",
+			content: "[search]\nfallback_tag = \"\"\"go\"\"\"\n",
+			line:    ":2:",
+			message: "многострочные строки",
+		},
+		{
+			name:    "незакрытая строка",
+			content: "[search]\nfallback_tag = 'go\n",
+			line:    ":2:",
+			message: "незакрытая строка",
+		},
+		{
+			name:    "пустое значение",
+			content: "[search]\nfallback_tag = # потом заполню\n",
+			line:    ":2:",
+			message: "пустое значение",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeConfig(t, tt.content)
+			_, err := parseFile(path)
+			if err == nil {
+				t.Fatal("ошибки нет")
+			}
+			if !strings.HasPrefix(err.Error(), path+tt.line) {
+				t.Errorf("ошибка %q, ждали позицию %s", err, tt.line)
+			}
+			if !strings.Contains(err.Error(), tt.message) {
+				t.Errorf("ошибка %q, ждали %q", err, tt.message)
+			}
+		})
+	}
+}
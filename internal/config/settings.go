@@ -0,0 +1,104 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// setting описывает один параметр: ключ в файле, переменные окружения и флаг
+type setting struct {
+	key   string   // ключ в файле: "секция.имя"
+	env   []string // переменные окружения; первая непустая побеждает
+	usage string
+	set   func(c *Config, val string) error
+}
+
+// flagName — имя флага из ключа: "hh.base_url" → "hh-base-url"
+func (s setting) flagName() string {
+	return strings.NewReplacer(".", "-", "_", "-").Replace(s.key)
+}
+
+var settings = []setting{
+	{"telegram.token", []string{"TELEGRAM_BOT_TOKEN", "TELEGRAM_TOKEN", "BOT_TOKEN"}, "токен Telegram-бота",
+		func(c *Config, v string) error { c.TelegramBotToken = v; return nil }},
+	{"telegram.chat_id", []string{"TELEGRAM_CHAT_ID"}, "chatID владельца (он же администратор)",
+		func(c *Config, v string) error { c.TelegramChatId = v; return nil }},
+	{"telegram.admin_ids", []string{"ADMIN_IDS"}, "администраторы через запятую",
+		func(c *Config, v string) (err error) { c.AdminIDs, err = parseIDs(v); return err }},
+
+	{"redis.addr", []string{"REDIS_ADDR"}, "адрес Redis",
+		func(c *Config, v string) error { c.RedisAddr = v; return nil }},
+	{"redis.password", []string{"REDIS_PASSWORD"}, "пароль Redis",
+		func(c *Config, v string) error { c.RedisPassword = v; return nil }},
+	{"redis.db", []string{"REDIS_DB"}, "номер базы Redis",
+		func(c *Config, v string) (err error) { c.RedisDB, err = strconv.Atoi(v); return err }},
+
+	{"http.addr", []string{"HTTP_ADDR"}, "адрес HTTP-сервера метрик (пусто — не запускать)",
+		func(c *Config, v string) error { c.HTTPAddr = v; return nil }},
+	{"http.read_timeout", []string{"HTTP_READ_TIMEOUT"}, "таймаут чтения заголовков HTTP-запроса",
+		durationSetter(func(c *Config) *time.Duration { return &c.HTTPReadTimeout })},
+	{"http.public_url", []string{"PUBLIC_URL"}, "внешний адрес HTTP-сервера для ссылок на ленты",
+		func(c *Config, v string) error { c.PublicURL = strings.TrimRight(v, "/"); return nil }},
+
+	{"log.level", []string{"LOG_LEVEL"}, "уровень логов: debug, info, warn, error",
+		func(c *Config, v string) error { c.LogLevel = v; return nil }},
+	{"log.format", []string{"LOG_FORMAT"}, "формат логов: text или json",
+		func(c *Config, v string) error { c.LogFormat = v; return nil }},
+
+	{"hh.base_url", []string{"HH_BASE_URL"}, "адрес API hh.ru",
+		func(c *Config, v string) error { c.HH.BaseURL = strings.TrimRight(v, "/"); return nil }},
+	{"hh.user_agent", []string{"HH_USER_AGENT"}, "User-Agent запросов к hh.ru",
+		func(c *Config, v string) error { c.HH.UserAgent = v; return nil }},
+	{"hh.timeout", []string{"HH_TIMEOUT"}, "таймаут запроса к hh.ru",
+		durationSetter(func(c *Config) *time.Duration { return &c.HH.Timeout })},
+
+	{"search.default_interval", []string{"DEFAULT_INTERVAL"}, "интервал проверки по умолчанию",
+		durationSetter(func(c *Config) *time.Duration { return &c.Search.DefaultInterval })},
+	{"search.min_interval", []string{"MIN_INTERVAL"}, "минимальный интервал, который может выбрать пользователь",
+		durationSetter(func(c *Config) *time.Duration { return &c.Search.MinInterval })},
+	{"search.fallback_areas", []string{"FALLBACK_AREAS"}, "регионы hh.ru, если города не указаны",
+		func(c *Config, v string) error { c.Search.FallbackAreas = splitList(v); return nil }},
+	{"search.fallback_tag", []string{"FALLBACK_TAG"}, "запрос, если теги не указаны",
+		func(c *Config, v string) error { c.Search.FallbackTag = v; return nil }},
+	{"search.seen_ttl", []string{"SEEN_TTL"}, "сколько помнить отправленные вакансии",
+		durationSetter(func(c *Config) *time.Duration { return &c.Search.SeenTTL })},
+	{"search.history_size", []string{"HISTORY_SIZE"}, "сколько последних совпадений поиска хранить для лент",
+		func(c *Config, v string) (err error) { c.Search.HistorySize, err = strconv.Atoi(v); return err }},
+	{"search.history_retention", []string{"HISTORY_RETENTION"}, "сколько хранить историю отправленных вакансий",
+		durationSetter(func(c *Config) *time.Duration { return &c.Search.HistoryRetention })},
+
+	{"sources.superjob_key", []string{"SUPERJOB_API_KEY"}, "ключ приложения SuperJob (пусто — источник выключен)",
+		func(c *Config, v string) error { c.Sources.SuperJobKey = v; return nil }},
+	{"sources.trudvsem", []string{"TRUDVSEM_ENABLED"}, "искать в открытом API «Работа России»",
+		func(c *Config, v string) (err error) { c.Sources.Trudvsem, err = strconv.ParseBool(v); return err }},
+	{"sources.rss_feeds", []string{"RSS_FEEDS"}, "ленты вакансий RSS/Atom через запятую, {query} — запрос",
+		func(c *Config, v string) error { c.Sources.RSSFeeds = splitList(v); return nil }},
+
+	{"notify.smtp_host", []string{"SMTP_HOST"}, "SMTP-сервер для писем (пусто — почта выключена)",
+		func(c *Config, v string) error { c.Notify.SMTPHost = v; return nil }},
+	{"notify.smtp_port", []string{"SMTP_PORT"}, "порт SMTP-сервера",
+		func(c *Config, v string) (err error) { c.Notify.SMTPPort, err = strconv.Atoi(v); return err }},
+	{"notify.smtp_username", []string{"SMTP_USERNAME"}, "логин SMTP",
+		func(c *Config, v string) error { c.Notify.SMTPUsername = v; return nil }},
+	{"notify.smtp_password", []string{"SMTP_PASSWORD"}, "пароль SMTP",
+		func(c *Config, v string) error { c.Notify.SMTPPassword = v; return nil }},
+	{"notify.smtp_from", []string{"SMTP_FROM"}, "адрес отправителя писем",
+		func(c *Config, v string) error { c.Notify.SMTPFrom = v; return nil }},
+	{"notify.matrix_homeserver", []string{"MATRIX_HOMESERVER"}, "адрес сервера Matrix (пусто — Matrix выключен)",
+		func(c *Config, v string) error { c.Notify.MatrixHomeserver = v; return nil }},
+	{"notify.matrix_token", []string{"MATRIX_TOKEN"}, "токен доступа аккаунта бота в Matrix",
+		func(c *Config, v string) error { c.Notify.MatrixToken = v; return nil }},
+}
+
+func durationSetter(field func(c *Config) *time.Duration) func(c *Config, v string) error {
+	return func(c *Config, v string) error {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("некорректная длительность %q (пример: 30s, 5m, 168h)", v)
+		}
+		*field(c) = d
+		return nil
+	}
+}
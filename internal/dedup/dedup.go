@@ -0,0 +1,145 @@
+// Поиск перепубликованных и дублирующихся вакансий с разными ID
+package dedup
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"hhruBot/internal/hh"
+	"hhruBot/internal/salary"
+)
+
+// Порог сходства сниппетов, начиная с которого вакансии считаем одной и той же
+const SimilarityThreshold = 0.6
+
+// Fingerprint строит отпечаток вакансии из нормализованного названия,
+// работодателя и вилки зарплаты. Город в отпечаток не входит намеренно.
+// Работодатель берётся по названию, а не по ID: у каждого источника свои ID,
+// и одна вакансия с hh.ru и SuperJob иначе не совпала бы.
+func Fingerprint(v hh.Vacancy) string {
+	var salaryPart string
+	if s := v.Salary; s != nil {
+		currency := salary.NormalizeCurrency(s.Currency)
+		if currency == "" {
+			currency = salary.BaseCurrency
+		}
+		salaryPart = fmt.Sprintf("%d-%d-%s", intOrZero(s.From), intOrZero(s.To), currency)
+	}
+
+	sum := sha1.Sum([]byte(NormalizeTitle(v.Name) + "|" + NormalizeEmployer(v.Employer.Name) + "|" + salaryPart))
+	return hex.EncodeToString(sum[:])
+}
+
+// Организационно-правовые формы, которые источники пишут по-разному или опускают
+var legalForms = map[string]bool{
+	"ооо": true, "оао": true, "зао": true, "пао": true, "ао": true, "нао": true,
+	"ип": true, "гк": true, "ано": true, "фгуп": true, "гбу": true, "мбу": true,
+	"llc": true, "ltd": true, "inc": true, "gmbh": true, "corp": true, "co": true,
+}
+
+// NormalizeEmployer приводит название работодателя к виду, общему для всех
+// источников: «ООО "Яндекс"» и «Яндекс» дают «яндекс»
+func NormalizeEmployer(name string) string {
+	var kept []string
+	for _, w := range strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if !legalForms[w] {
+			kept = append(kept, w)
+		}
+	}
+	return strings.Join(kept, " ")
+}
+
+// NormalizeTitle приводит название к нижнему регистру и убирает пунктуацию и пояснения в скобках
+func NormalizeTitle(title string) string {
+	var b strings.Builder
+	depth := 0
+	for _, r := range strings.ToLower(title) {
+		switch {
+		case r == '(' || r == '[':
+			depth++
+		case r == ')' || r == ']':
+			if depth > 0 {
+				depth--
+			}
+		case depth > 0:
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+		default:
+			b.WriteRune(' ')
+		}
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// Similarity — коэффициент Жаккара по словам двух текстов (0..1).
+// Пустой текст ни на что не похож: без слов сходство не доказать.
+func Similarity(a, b string) float64 {
+	wa, wb := words(a), words(b)
+	if len(wa) == 0 || len(wb) == 0 {
+		return 0
+	}
+
+	common := 0
+	for w := range wa {
+		if wb[w] {
+			common++
+		}
+	}
+	return float64(common) / float64(len(wa)+len(wb)-common)
+}
+
+// Group объединяет дубликаты: одинаковый отпечаток и похожий сниппет
+// (требования и обязанности). Полные описания при поиске не запрашиваются,
+// а сниппет заполняют все источники. Порядок групп и элементов внутри них
+// сохраняет порядок входа.
+func Group[T any](items []T, vacancy func(T) hh.Vacancy) [][]T {
+	var groups [][]T
+	fingerprints := make([]string, 0)
+
+	for _, item := range items {
+		v := vacancy(item)
+		fp := Fingerprint(v)
+
+		placed := false
+		for i, group := range groups {
+			if fingerprints[i] != fp {
+				continue
+			}
+			if Similarity(vacancy(group[0]).SnippetText(), v.SnippetText()) >= SimilarityThreshold {
+				groups[i] = append(groups[i], item)
+				placed = true
+				break
+			}
+		}
+
+		if !placed {
+			groups = append(groups, []T{item})
+			fingerprints = append(fingerprints, fp)
+		}
+	}
+	return groups
+}
+
+func words(text string) map[string]bool {
+	set := make(map[string]bool)
+	for _, w := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if len([]rune(w)) > 2 {
+			set[w] = true
+		}
+	}
+	return set
+}
+
+func intOrZero(p *int) int {
+	if p == nil {
+		return 0
+	}
+	return *p
+}
@@ -0,0 +1,240 @@
+// Ленты совпавших вакансий в форматах RSS 2.0, Atom и JSON Feed
+package feed
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/url"
+	"time"
+)
+
+// Форматы лент; значение — расширение в адресе ленты
+const (
+	RSS  = "rss"
+	Atom = "atom"
+	JSON = "json"
+)
+
+// ContentTypes — заголовок Content-Type для каждого формата
+var ContentTypes = map[string]string{
+	RSS:  "application/rss+xml; charset=utf-8",
+	Atom: "application/atom+xml; charset=utf-8",
+	JSON: "application/feed+json; charset=utf-8",
+}
+
+// Feed — лента одного поиска
+type Feed struct {
+	Title       string
+	Description string
+	SelfURL     string // адрес самой ленты; пусто, если внешний адрес сервера не задан
+	Updated     time.Time
+	Items       []Item
+}
+
+// Item — запись ленты
+type Item struct {
+	ID        string
+	Title     string
+	URL       string
+	Summary   string
+	Author    string // работодатель
+	Tags      []string
+	Published time.Time
+}
+
+// Write пишет ленту в нужном формате
+func (f Feed) Write(w io.Writer, format string) error {
+	switch format {
+	case RSS:
+		return f.writeRSS(w)
+	case Atom:
+		return f.writeAtom(w)
+	case JSON:
+		return f.writeJSON(w)
+	}
+	return fmt.Errorf("неизвестный формат ленты %q", format)
+}
+
+type rssLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type rssItem struct {
+	Title       string   `xml:"title"`
+	Link        string   `xml:"link"`
+	GUID        rssGUID  `xml:"guid"`
+	Description string   `xml:"description,omitempty"`
+	Categories  []string `xml:"category"`
+	PubDate     string   `xml:"pubDate"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type rssDoc struct {
+	XMLName xml.Name `xml:"rss"`
+	Version string   `xml:"version,attr"`
+	AtomNS  string   `xml:"xmlns:atom,attr"`
+	Channel struct {
+		Title         string    `xml:"title"`
+		Link          string    `xml:"link"`
+		Description   string    `xml:"description"`
+		LastBuildDate string    `xml:"lastBuildDate"`
+		Self          *rssLink  `xml:"atom:link,omitempty"`
+		Items         []rssItem `xml:"item"`
+	} `xml:"channel"`
+}
+
+func (f Feed) writeRSS(w io.Writer) error {
+	doc := rssDoc{Version: "2.0", AtomNS: "http://www.w3.org/2005/Atom"}
+	doc.Channel.Title = f.Title
+	doc.Channel.Link = f.SelfURL
+	doc.Channel.Description = f.Description
+	doc.Channel.LastBuildDate = f.Updated.Format(time.RFC1123Z)
+	if f.SelfURL != "" {
+		doc.Channel.Self = &rssLink{Href: f.SelfURL, Rel: "self", Type: "application/rss+xml"}
+	}
+	for _, it := range f.Items {
+		doc.Channel.Items = append(doc.Channel.Items, rssItem{
+			Title:       it.Title,
+			Link:        it.URL,
+			GUID:        rssGUID{Value: it.ID},
+			Description: it.Summary,
+			Categories:  it.Tags,
+			PubDate:     it.Published.Format(time.RFC1123Z),
+		})
+	}
+	return encodeXML(w, doc)
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+}
+
+type atomEntry struct {
+	Title      string         `xml:"title"`
+	ID         string         `xml:"id"`
+	Link       atomLink       `xml:"link"`
+	Updated    string         `xml:"updated"`
+	Summary    string         `xml:"summary,omitempty"`
+	Author     *atomAuthor    `xml:"author,omitempty"`
+	Categories []atomCategory `xml:"category"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomDoc struct {
+	XMLName  xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title    string      `xml:"title"`
+	Subtitle string      `xml:"subtitle,omitempty"`
+	ID       string      `xml:"id"`
+	Updated  string      `xml:"updated"`
+	Links    []atomLink  `xml:"link"`
+	Author   atomAuthor  `xml:"author"`
+	Entries  []atomEntry `xml:"entry"`
+}
+
+func (f Feed) writeAtom(w io.Writer) error {
+	doc := atomDoc{
+		Title:    f.Title,
+		Subtitle: f.Description,
+		ID:       f.SelfURL,
+		Updated:  f.Updated.Format(time.RFC3339),
+		Author:   atomAuthor{Name: "hhruBot"},
+	}
+	if doc.ID == "" {
+		// Atom требует постоянный идентификатор ленты
+		doc.ID = "urn:hhrubot:" + url.PathEscape(f.Title)
+	} else {
+		doc.Links = append(doc.Links, atomLink{Href: f.SelfURL, Rel: "self"})
+	}
+	for _, it := range f.Items {
+		entry := atomEntry{
+			Title:   it.Title,
+			ID:      it.ID,
+			Link:    atomLink{Href: it.URL, Rel: "alternate"},
+			Updated: it.Published.Format(time.RFC3339),
+			Summary: it.Summary,
+		}
+		if it.Author != "" {
+			entry.Author = &atomAuthor{Name: it.Author}
+		}
+		for _, tag := range it.Tags {
+			entry.Categories = append(entry.Categories, atomCategory{Term: tag})
+		}
+		doc.Entries = append(doc.Entries, entry)
+	}
+	return encodeXML(w, doc)
+}
+
+func encodeXML(w io.Writer, doc any) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	return enc.Encode(doc)
+}
+
+type jsonItem struct {
+	ID            string       `json:"id"`
+	URL           string       `json:"url"`
+	Title         string       `json:"title"`
+	ContentText   string       `json:"content_text"`
+	DatePublished string       `json:"date_published"`
+	Authors       []jsonAuthor `json:"authors,omitempty"`
+	Tags          []string     `json:"tags,omitempty"`
+}
+
+type jsonAuthor struct {
+	Name string `json:"name"`
+}
+
+type jsonDoc struct {
+	Version     string     `json:"version"`
+	Title       string     `json:"title"`
+	Description string     `json:"description,omitempty"`
+	FeedURL     string     `json:"feed_url,omitempty"`
+	Items       []jsonItem `json:"items"`
+}
+
+func (f Feed) writeJSON(w io.Writer) error {
+	doc := jsonDoc{
+		Version:     "https://jsonfeed.org/version/1.1",
+		Title:       f.Title,
+		Description: f.Description,
+		FeedURL:     f.SelfURL,
+		Items:       []jsonItem{},
+	}
+	for _, it := range f.Items {
+		item := jsonItem{
+			ID:            it.ID,
+			URL:           it.URL,
+			Title:         it.Title,
+			ContentText:   it.Summary,
+			DatePublished: it.Published.Format(time.RFC3339),
+			Tags:          it.Tags,
+		}
+		if it.Author != "" {
+			item.Authors = []jsonAuthor{{Name: it.Author}}
+		}
+		doc.Items = append(doc.Items, item)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
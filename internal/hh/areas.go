@@ -0,0 +1,43 @@
+package hh
+
+import "strings"
+
+// GetAreas загружает дерево регионов hh.ru: страны, регионы, города
+func (c *Client) GetAreas() ([]Area, error) {
+	var countries []Area
+	if err := c.getJSON(c.apiRoot+"/areas", &countries); err != nil {
+		return nil, err
+	}
+	return countries, nil
+}
+
+// AreaMatch — регион, найденный по названию, и путь к нему: "Россия / Московская область"
+type AreaMatch struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Path string `json:"path,omitempty"`
+}
+
+// FindAreas ищет регионы, в названии которых есть query (без учёта регистра).
+// Точные совпадения идут первыми, дальше — в порядке обхода дерева.
+func FindAreas(areas []Area, query string) []AreaMatch {
+	query = strings.ToLower(strings.TrimSpace(query))
+	var exact, partial []AreaMatch
+	var walk func(areas []Area, path []string)
+	walk = func(areas []Area, path []string) {
+		for _, a := range areas {
+			name := strings.ToLower(a.Name)
+			if strings.Contains(name, query) {
+				m := AreaMatch{ID: a.ID, Name: a.Name, Path: strings.Join(path, " / ")}
+				if name == query {
+					exact = append(exact, m)
+				} else {
+					partial = append(partial, m)
+				}
+			}
+			walk(a.Areas, append(path, a.Name))
+		}
+	}
+	walk(areas, nil)
+	return append(exact, partial...)
+}
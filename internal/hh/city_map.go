@@ -1,14 +1,15 @@
 package hh
 
 import (
-	"encoding/json"
 	"fmt"
-	"net/http"
 	"strings"
 )
 
 var cityMap = make(map[string]string)
 
+// areaNames — название региона по коду, обратная карта к cityMap
+var areaNames = make(map[string]string)
+
 type Area struct {
 	ID     string  `json:"id"`
 	Name   string  `json:"name"`
@@ -16,17 +17,11 @@ type Area struct {
 }
 
 // InitCityMap загружает список всех городов и строит карту для поиска
-func InitCityMap() error {
-	resp, err := http.Get("https://api.hh.ru/areas")
+func (c *Client) InitCityMap() error {
+	countries, err := c.GetAreas()
 	if err != nil {
 		return fmt.Errorf("ошибка запроса городов HH: %w", err)
 	}
-	defer resp.Body.Close()
-
-	var countries []Area
-	if err := json.NewDecoder(resp.Body).Decode(&countries); err != nil {
-		return fmt.Errorf("ошибка декодирования JSON: %w", err)
-	}
 
 	for _, country := range countries {
 		walkAreas(country)
@@ -41,13 +36,29 @@ func walkAreas(area Area) {
 	if _, exists := cityMap[lowerName]; !exists && area.ID != "" {
 		cityMap[lowerName] = area.ID
 	}
+	if _, exists := areaNames[area.ID]; !exists && area.ID != "" {
+		areaNames[area.ID] = area.Name
+	}
 
 	for _, subArea := range area.Areas {
 		walkAreas(subArea)
 	}
 }
 
+// CityMapReady сообщает, загружена ли карта городов (для /readyz)
+func CityMapReady() error {
+	if len(cityMap) == 0 {
+		return fmt.Errorf("карта городов не загружена")
+	}
+	return nil
+}
+
 // CityToAreaID возвращает код региона для города
 func CityToAreaID(name string) string {
 	return cityMap[strings.ToLower(strings.TrimSpace(name))]
 }
+
+// AreaName возвращает название региона по коду, пустое — если код неизвестен
+func AreaName(id string) string {
+	return areaNames[id]
+}
@@ -2,12 +2,17 @@ package hh
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
+
+	"hhruBot/internal/logging"
 )
 
 type Vacancy struct {
@@ -16,23 +21,100 @@ type Vacancy struct {
 	Area struct {
 		Name string `json:"name"`
 	} `json:"area"`
+	Salary   *Salary `json:"salary"`
+	Employer struct {
+		Id   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"employer"`
+	Snippet struct {
+		Requirement    string `json:"requirement"`
+		Responsibility string `json:"responsibility"`
+	} `json:"snippet"`
+	PublishedAt string `json:"published_at"`
+	Archived    bool   `json:"archived"`
+	URL         string `json:"alternate_url"`
+
+	// Источник вакансии: "hh" или имя другого источника (см. пакет sources)
+	Source string `json:"source,omitempty"`
+
+	// Заполняются только при запросе конкретной вакансии (GetVacancy)
+	Description string     `json:"description"`
+	KeySkills   []KeySkill `json:"key_skills"`
+}
+
+type Salary struct {
+	From     *int   `json:"from"`
+	To       *int   `json:"to"`
+	Currency string `json:"currency"`
+	Gross    *bool  `json:"gross"`
+}
+
+type KeySkill struct {
+	Name string `json:"name"`
+}
+
+// SnippetText возвращает требования и обязанности из сниппета без подсветки hh.ru
+func (v Vacancy) SnippetText() string {
+	text := v.Snippet.Requirement + " " + v.Snippet.Responsibility
+	text = strings.ReplaceAll(text, "<highlighttext>", "")
+	text = strings.ReplaceAll(text, "</highlighttext>", "")
+	return strings.TrimSpace(text)
 }
 
 type ResponseHH struct {
 	Items []Vacancy `json:"items"`
+	Found int       `json:"found"`
+	Pages int       `json:"pages"`
+	Page  int       `json:"page"`
+}
+
+// Варианты сортировки поиска hh.ru
+const (
+	OrderByDate      = "publication_time"
+	OrderBySalary    = "salary_desc"
+	OrderByRelevance = "relevance"
+)
+
+// SearchQuery — параметры разового поиска без ограничения по дате публикации
+type SearchQuery struct {
+	Text    string
+	Areas   []string
+	OrderBy string
+	Page    int
+	PerPage int
+}
+
+// ErrNotFound — вакансия или работодатель удалены либо скрыты
+var ErrNotFound = errors.New("hh.ru: объект не найден")
+
+// RequestObserver получает результат каждого запроса к API hh.ru (для статистики)
+type RequestObserver func(status int, duration time.Duration, err error)
+
+// Options — настройки клиента, берутся из конфигурации бота
+type Options struct {
+	APIRoot       string // например https://api.hh.ru
+	UserAgent     string
+	Timeout       time.Duration
+	FallbackText  string   // запрос, если теги не указаны
+	FallbackAreas []string // регионы, если города не указаны
 }
 
 type Client struct {
-	baseURL string
-	client  *http.Client
+	apiRoot  string
+	baseURL  string
+	client   *http.Client
+	opts     Options
+	Observer RequestObserver
 }
 
-func NewClient() *Client {
+func NewClient(opts Options) *Client {
 	return &Client{
-		baseURL: "https://api.hh.ru/vacancies",
+		apiRoot: opts.APIRoot,
+		baseURL: opts.APIRoot + "/vacancies",
 		client: &http.Client{
-			Timeout: 10 * time.Second,
+			Timeout: opts.Timeout,
 		},
+		opts: opts,
 	}
 }
 
@@ -46,7 +128,7 @@ func (c *Client) GetVacancies(tags, cities []string, from time.Time) ([]Vacancy,
 		}
 		params.Set("text", strings.Join(tags, " OR "))
 	} else {
-		params.Set("text", "golang") // fallback
+		params.Set("text", c.opts.FallbackText) // fallback
 	}
 
 	// Города (area)
@@ -58,10 +140,11 @@ func (c *Client) GetVacancies(tags, cities []string, from time.Time) ([]Vacancy,
 		}
 	}
 
-	// Если пользователь ничего не указал — ищем в Москве и СПб
+	// Если пользователь ничего не указал — ищем в регионах по умолчанию (Москва и СПб)
 	if !addedCity {
-		params.Add("area", "1") // Москва
-		params.Add("area", "2") // Санкт-Петербург
+		for _, area := range c.opts.FallbackAreas {
+			params.Add("area", area)
+		}
 	}
 
 	params.Set("order_by", "publication_time")
@@ -72,27 +155,150 @@ func (c *Client) GetVacancies(tags, cities []string, from time.Time) ([]Vacancy,
 
 	url := fmt.Sprintf("%s?%s", c.baseURL, params.Encode())
 
+	var data ResponseHH
+	if err := c.getJSON(url, &data); err != nil {
+		return nil, err
+	}
+
+	return data.Items, nil
+}
+
+// Search выполняет разовый поиск вакансий и возвращает одну страницу результатов
+func (c *Client) Search(q SearchQuery) (*ResponseHH, error) {
+	params := url.Values{}
+	params.Set("text", q.Text)
+	for _, area := range q.Areas {
+		params.Add("area", area)
+	}
+	if q.OrderBy != "" {
+		params.Set("order_by", q.OrderBy)
+	}
+	params.Set("page", strconv.Itoa(q.Page))
+	params.Set("per_page", strconv.Itoa(q.PerPage))
+
+	var data ResponseHH
+	if err := c.getJSON(fmt.Sprintf("%s?%s", c.baseURL, params.Encode()), &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// GetVacancy загружает полную карточку вакансии (описание, ключевые навыки)
+func (c *Client) GetVacancy(id string) (*Vacancy, error) {
+	var v Vacancy
+	if err := c.getJSON(fmt.Sprintf("%s/%s", c.baseURL, id), &v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// APIError — ответ hh.ru с неуспешным HTTP-статусом
+type APIError struct {
+	Status int
+	Body   string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("hh.ru error %d: %s", e.Status, e.Body)
+}
+
+// StatusOf возвращает HTTP-статус ответа hh.ru из ошибки (0, если это не ошибка API)
+func StatusOf(err error) int {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Status
+	}
+	return 0
+}
+
+const (
+	maxAttempts  = 3
+	retryBackoff = 2 * time.Second
+)
+
+// getJSON выполняет GET-запрос к API hh.ru и декодирует ответ в dst.
+// Сетевые ошибки, 429 и 5xx повторяются до maxAttempts раз.
+func (c *Client) getJSON(url string, dst any) error {
+	var err error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		err = c.doGetJSON(url, dst)
+		if err == nil || !retryable(err) {
+			return err
+		}
+		slog.Warn("запрос к hh.ru не удался, повторяем",
+			logging.KeyAttempt, attempt, logging.KeyHHStatus, StatusOf(err), logging.Err(err))
+		if attempt < maxAttempts {
+			time.Sleep(time.Duration(attempt) * retryBackoff)
+		}
+	}
+	return err
+}
+
+func (c *Client) doGetJSON(url string, dst any) error {
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	req.Header.Set("User-Agent", "golang-job-bot/1.0")
+	req.Header.Set("User-Agent", c.opts.UserAgent)
 
+	start := time.Now()
 	resp, err := c.client.Do(req)
+	if c.Observer != nil {
+		status := 0
+		if resp != nil {
+			status = resp.StatusCode
+		}
+		c.Observer(status, time.Since(start), err)
+	}
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("hh.ru error: %s", string(body))
+		return &APIError{Status: resp.StatusCode, Body: string(body)}
 	}
 
-	var data ResponseHH
-	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+	return json.NewDecoder(resp.Body).Decode(dst)
+}
+
+func retryable(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Status == http.StatusTooManyRequests || apiErr.Status >= 500
+	}
+	var netErr *url.Error
+	return errors.As(err, &netErr)
+}
+
+type Currency struct {
+	Code string  `json:"code"`
+	Abbr string  `json:"abbr"`
+	Name string  `json:"name"`
+	Rate float64 `json:"rate"`
+}
+
+type dictionaries struct {
+	Currency []Currency `json:"currency"`
+}
+
+// GetCurrencyRates загружает курсы валют из справочников hh.ru.
+// Курс — сколько единиц валюты дают за один рубль.
+func (c *Client) GetCurrencyRates() (map[string]float64, error) {
+	var data dictionaries
+	if err := c.getJSON(c.apiRoot+"/dictionaries", &data); err != nil {
 		return nil, err
 	}
 
-	return data.Items, nil
+	rates := make(map[string]float64, len(data.Currency))
+	for _, cur := range data.Currency {
+		if cur.Rate > 0 {
+			rates[cur.Code] = cur.Rate
+		}
+	}
+	return rates, nil
 }
@@ -0,0 +1,74 @@
+package hh
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+type Employer struct {
+	Id            string `json:"id"`
+	Name          string `json:"name"`
+	AlternateURL  string `json:"alternate_url"`
+	OpenVacancies int    `json:"open_vacancies"`
+}
+
+type employersResponse struct {
+	Items []Employer `json:"items"`
+}
+
+// SearchEmployers ищет работодателей по названию через /employers?text=
+func (c *Client) SearchEmployers(text string) ([]Employer, error) {
+	params := url.Values{}
+	params.Set("text", text)
+	params.Set("only_with_vacancies", "true")
+	params.Set("per_page", "10")
+
+	var data employersResponse
+	if err := c.getJSON(fmt.Sprintf("%s/employers?%s", c.apiRoot, params.Encode()), &data); err != nil {
+		return nil, err
+	}
+	return data.Items, nil
+}
+
+// GetEmployer загружает карточку работодателя по ID
+func (c *Client) GetEmployer(id string) (*Employer, error) {
+	var e Employer
+	if err := c.getJSON(fmt.Sprintf("%s/employers/%s", c.apiRoot, id), &e); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// hh.ru отдаёт по одному запросу не больше 2000 вакансий: 20 страниц по 100
+const (
+	employerVacanciesPerPage  = 100
+	employerVacanciesMaxPages = 20
+)
+
+// GetEmployerVacancies возвращает вакансии работодателей, опубликованные после from, без учёта тегов и городов.
+// Проходит все страницы выдачи.
+func (c *Client) GetEmployerVacancies(employerIDs []string, from time.Time) ([]Vacancy, error) {
+	params := url.Values{}
+	for _, id := range employerIDs {
+		params.Add("employer_id", id)
+	}
+	params.Set("order_by", "publication_time")
+	params.Set("per_page", strconv.Itoa(employerVacanciesPerPage))
+	params.Set("date_from", from.Format(time.RFC3339))
+
+	var vacancies []Vacancy
+	for page := 0; page < employerVacanciesMaxPages; page++ {
+		params.Set("page", strconv.Itoa(page))
+		var data ResponseHH
+		if err := c.getJSON(fmt.Sprintf("%s?%s", c.baseURL, params.Encode()), &data); err != nil {
+			return nil, err
+		}
+		vacancies = append(vacancies, data.Items...)
+		if page+1 >= data.Pages {
+			break
+		}
+	}
+	return vacancies, nil
+}
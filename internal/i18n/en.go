@@ -0,0 +1,494 @@
+package i18n
+
+var en = catalog{
+	plural: pluralEN,
+	plurals: map[string][]string{
+		"minutes":            {"%d minute", "%d minutes"},
+		"days":               {"%d day", "%d days"},
+		"market.with_salary": {"%d vacancy with salary", "%d vacancies with salary"},
+		"vacancies":          {"%d vacancy", "%d vacancies"},
+		"recipients":         {"%d recipient", "%d recipients"},
+	},
+	messages: map[string]string{
+		// Зарплаты
+		"salary.from":  "from %s",
+		"salary.to":    "up to %s",
+		"salary.net":   "net",
+		"salary.gross": "gross",
+		"salary.none":  "not specified",
+
+		// Оценка вакансий
+		"score.bad_weight":   "invalid weight in %q",
+		"score.empty_term":   "empty word in %q",
+		"score.in_title":     "%s in title",
+		"score.in_snippet":   "%s in description",
+		"score.unwanted":     "unwanted %s",
+		"score.skill":        "skill %s",
+		"score.salary":       "salary %s",
+		"score.salary_below": "salary below %d",
+
+		// Изменения отслеживаемых вакансий
+		"tracking.description_changed": "description changed significantly",
+		"tracking.archived":            "vacancy moved to archive",
+		"tracking.reopened":            "vacancy reopened",
+		"tracking.kind.salary":         "💰 Salary",
+		"tracking.kind.description":    "📝 Description",
+		"tracking.kind.archived":       "📦 Archived",
+		"tracking.kind.reopened":       "🔓 Reopened",
+
+		// Воронка откликов
+		"pipeline.saved":     "📌 Saved",
+		"pipeline.applied":   "📨 Applied",
+		"pipeline.interview": "🗣 Interview",
+		"pipeline.offer":     "🎉 Offer",
+		"pipeline.rejected":  "🚫 Rejected",
+
+		// Рынок
+		"market.no_tag":        "specify a tag",
+		"market.bad_period":    "period must be from 1d to %dd",
+		"market.all_cities":    "all cities",
+		"market.title":         "📊 Market: %s, %s, last %s",
+		"market.no_data":       "No data yet. Statistics are collected from the queries the bot checks for its users: add the tag to /tags or /search_add and come back later.",
+		"market.total":         "🆕 New vacancies: %d (%.1f per day on average)",
+		"market.salaries":      "💰 Net salaries (%s):",
+		"market.percentiles":   "   25%% — %s, median — %s\n   75%% — %s, 90%% — %s",
+		"market.no_salaries":   "💰 No vacancy has a salary specified",
+		"market.top_employers": "🏢 Most vacancies:",
+		"market.top_skills":    "🧠 Key skills (from vacancies with full details):",
+		"market.thousand_rub":  "%.0fk ₽",
+
+		// Источники вакансий
+		"source.hh":       "hh.ru",
+		"source.superjob": "SuperJob",
+		"source.trudvsem": "Rabota Rossii (trudvsem.ru)",
+		"source.rss":      "RSS feeds",
+
+		// Каналы доставки
+		"channel.telegram":    "Telegram",
+		"channel.email":       "Email",
+		"channel.slack":       "Slack",
+		"channel.discord":     "Discord",
+		"channel.matrix":      "Matrix",
+		"channel.webhook":     "Webhook",
+		"channel.bad_email":   "invalid email address",
+		"channel.bad_webhook": "a webhook URL like https://… is required",
+		"channel.bad_matrix":  "a room ID like !abcdef:matrix.org is required",
+		"channel.unknown":     "unknown channel %q",
+
+		// Карточка вакансии
+		"card.employer":     "Company: %s",
+		"card.area":         "City: %s",
+		"card.other_areas":  "Also in: %s",
+		"card.salary":       "Salary: %s",
+		"card.score":        "Score: %d",
+		"card.source":       "Source: %s",
+		"card.search":       "Search: %s",
+		"card.subject_one":  "New vacancy: %s",
+		"card.subject_many": "New vacancies: %d",
+
+		// Язык
+		"lang.usage":   "Choose a language: /lang ru, /lang en or /lang auto to follow your Telegram settings",
+		"lang.current": "🌐 Bot language: %s\nPick another one or “Same as Telegram” to follow the app language.",
+		"lang.saved":   "🌐 Bot language: %s",
+		"lang.auto":    "Same as Telegram",
+
+		// Команды
+		"layout.datetime":           "2006-01-02 15:04",
+		"layout.datetime_at":        "2006-01-02 at 15:04",
+		"layout.date":               "2006-01-02",
+		"unknown_command":           "Unknown command. Try /start",
+		"group.admins_only":         "🔒 Only administrators can change the bot settings in this chat.",
+		"start.group":               "👋 The bot is connected to “%s”.\n\nNew vacancies will be posted here. Only chat administrators can configure the search:\n/tags golang,devops — keywords\n/city Berlin — city or cities\n/thread 123 — post to a forum topic (topic ID from its link)\n/settings — current settings\n/lang ru — bot language\n/help — command reference",
+		"start.private":             "👋 Welcome to the HH.ru Bot!\n\nI'll help you keep track of new vacancies.\n\n⚙️ Main commands:\n/find golang Moscow — find vacancies right now\n/tags golang,devops — set keywords\n/city Moscow — choose city or cities\n/interval 30 — check interval (in minutes)\n/keywords golang:3,-1c:5 — words for scoring vacancies\n/skills go,docker — key skills\n/minsalary 200000 — minimum salary\n/threshold 3 — minimum score to send a vacancy\n/currency USD — currency for salaries\n/salary_basis net — salary after tax (net) or before tax (gross)\n/track 123456 — follow changes of a vacancy\n/watch_employer Yandex — all new vacancies of a company\n/saved — saved vacancies, /pipeline — application pipeline\n/remind 123456 2026-10-21 14:00 — interview reminder\n/tracked — followed vacancies\n/pause — pause notifications\n/search — resume\n/settings — show current settings\n/lang ru — переключить на русский\n/help — command reference",
+		"help":                      "🛠 Available commands:\n/find — one-off search with paging: /find <query> [city]\n/tags — set keywords\n/city — choose cities\n/searches — all searches: main and saved\n/search_add — one more search: /search_add Name | tags | cities\n/search_del — delete a saved search\n/sources — search sources: hh.ru, SuperJob, Rabota Rossii, RSS feeds\n/channels — delivery channels: email, Slack, Discord, Matrix, webhook\n/channel_add — add a channel: /channel_add slack https://hooks.slack.com/…\n/channel_confirm — confirm an email with the code from the message\n/channel_del — delete a channel\n/route — where to send vacancies: /route [search id] telegram,<channel id>\n/history — sent vacancies, search: /history yandex\n/export — export the history as a file: /export csv, json or xlsx\n/market — market by tag: vacancies, salaries, employers and skills, e.g. /market golang Moscow 30d\n/feed — search feeds in RSS, Atom and JSON Feed for readers and automations\n/feed_reset — issue a new feed token, old addresses stop working\n/apitoken — HTTP API token for scripts (/apitoken revoke — revoke)\n/interval — search frequency (in minutes)\n/keywords — weighted words for scoring (minus — unwanted, off — clear)\n/skills — weighted key skills (off — clear)\n/minsalary — minimum salary\n/threshold — minimum vacancy score\n/currency — currency to show salaries in\n/salary_basis — net or gross\n/track — follow a vacancy (or ⭐ under the card)\n/untrack — stop following\n/tracked — followed vacancies\n/changes — change history of a vacancy\n/watch_employer — follow all vacancies of a company\n/unwatch_employer — stop following a company\n/employers — followed companies\n/employer_interval — company check interval (in minutes)\n/save — save a vacancy (or 📌 under the card)\n/saved — saved vacancies with status buttons\n/pipeline — pipeline: saved → applied → interview → offer → rejected\n/note — note for a saved vacancy\n/remind — reminder: /remind <vacancy> YYYY-MM-DD HH:MM [text]\n/reminders — scheduled reminders\n/unremind — cancel a reminder\n/followup — days after applying to remind you (0 — don't remind)\n/tz — time zone, e.g. Europe/Berlin\n/thread — forum topic for vacancies in a group (off — main chat)\n/lang — bot language: English or Russian\n/pause — stop notifications\n/search — resume notifications\n/settings — show current settings\n/mydata — archive of all data the bot keeps about this chat\n/deleteme — delete all data of this chat\n/help — show this help",
+		"tags.usage":                "Enter keywords after the command, e.g.:\n/tags golang,devops",
+		"tags.error":                "Failed to save tags",
+		"tags.saved":                "Tags saved: %s",
+		"city.usage":                "Enter cities after the command, e.g.:\n/city Moscow,Saint Petersburg",
+		"city.error":                "Failed to save cities",
+		"city.saved":                "Cities saved: %s",
+		"interval.usage":            "The interval must be a positive number of minutes, e.g.:\n/interval 30",
+		"interval.min":              "⚠ The minimum interval is %s.",
+		"interval.error":            "Failed to save the interval",
+		"interval.saved":            "Interval saved: %s.",
+		"keywords.usage":            "Enter weighted words after the command, minus marks an unwanted word, e.g.:\n/keywords golang:3, kubernetes, -1c:5\nTo clear: /keywords off",
+		"keywords.error":            "Failed to save keywords",
+		"keywords.cleared":          "Keywords cleared, scoring by them is off.",
+		"keywords.saved":            "Scoring keywords saved: %s",
+		"skills.usage":              "Enter key skills after the command, e.g.:\n/skills go, docker:3, postgresql\nTo clear: /skills off",
+		"skills.error":              "Failed to save skills",
+		"skills.cleared":            "Skills cleared, scoring by them is off.",
+		"skills.saved":              "Skills saved: %s",
+		"minsalary.usage":           "The minimum salary must be a number (0 — ignore), e.g.:\n/minsalary 200000",
+		"minsalary.error":           "Failed to save the salary",
+		"minsalary.saved":           "Minimum salary saved: %s",
+		"threshold.usage":           "The threshold must be an integer, e.g.:\n/threshold 3",
+		"threshold.error":           "Failed to save the threshold",
+		"threshold.saved":           "Relevance threshold saved: %s",
+		"currency.usage":            "Enter a currency code after the command, e.g.:\n/currency USD",
+		"currency.unknown":          "❌ Unknown currency: %s",
+		"currency.error":            "Failed to save the currency",
+		"currency.saved":            "Salaries will be shown in: %s",
+		"salary_basis.usage":        "Specify net (after tax) or gross (before tax), e.g.:\n/salary_basis net",
+		"salary_basis.error":        "Failed to save the salary basis",
+		"salary_basis.saved":        "Salary basis saved: %s",
+		"tracked.error":             "❌ Failed to get followed vacancies.",
+		"tracked.empty":             "You aren't following any vacancy yet. Tap ⭐ under a vacancy or use /track <id>",
+		"tracked.title":             "⭐ Followed vacancies:",
+		"tracked.archived":          " (archived)",
+		"tracked.line":              "%s — %s%s, salary: %s",
+		"tracked.footer":            "Change history: /changes <id>",
+		"untrack.usage":             "Specify a vacancy ID or link, e.g.:\n/untrack 123456",
+		"untrack.error":             "❌ Failed to stop following.",
+		"untrack.done":              "No longer following vacancy %s",
+		"track.usage":               "Specify a vacancy ID or link, e.g.:\n/track https://hh.ru/vacancy/123456",
+		"track.error":               "❌ Failed to load vacancy %s",
+		"track.done":                "⭐ Following “%s”. I'll let you know about changes in salary, description and archiving.",
+		"changes.usage":             "Specify a vacancy ID, e.g.:\n/changes 123456",
+		"changes.error":             "❌ Failed to get the change history.",
+		"changes.empty":             "No changes for vacancy %s yet.",
+		"changes.title":             "🕓 Change history of vacancy %s:",
+		"watch_employer.usage":      "Enter a company name after the command, e.g.:\n/watch_employer Yandex",
+		"watch_employer.error":      "❌ Failed to search for the employer.",
+		"watch_employer.not_found":  "No employers with open vacancies found for “%s”.",
+		"watch_employer.choose":     "Several employers found, choose one:",
+		"unwatch_employer.usage":    "Specify an employer ID from /employers, e.g.:\n/unwatch_employer 1740",
+		"unwatch_employer.error":    "❌ Failed to remove the employer.",
+		"unwatch_employer.done":     "No longer following employer %s",
+		"employers.error":           "❌ Failed to get the employer list.",
+		"employers.empty":           "You aren't following any employer. Add one: /watch_employer Yandex",
+		"employers.title":           "🏢 Followed employers:",
+		"employers.footer":          "Remove: /unwatch_employer <id>",
+		"employer_interval.usage":   "The interval must be a number of minutes not less than %d, e.g.:\n/employer_interval 60",
+		"employer_interval.saved":   "Employer check interval saved: %s.",
+		"save.usage":                "Specify a vacancy ID or link, e.g.:\n/save 123456",
+		"save.error":                "❌ Failed to save vacancy %s",
+		"note.usage":                "Specify a saved vacancy ID and the note text, e.g.:\n/note 123456 call with HR on Tuesday",
+		"note.not_found":            "❌ The vacancy isn't among saved ones. Save it first: /save <id>",
+		"note.added":                "🗒 Note added.",
+		"remind.example":            "Example: /remind 123456 2026-10-21 14:00 Zoom interview",
+		"remind.scheduled":          "⏰ I'll remind you on %s",
+		"unremind.usage":            "Specify a reminder ID from /reminders",
+		"unremind.error":            "❌ Failed to cancel the reminder.",
+		"unremind.not_found":        "Reminder %s not found — check the ID in /reminders.",
+		"unremind.done":             "Reminder cancelled.",
+		"followup.usage":            "Specify the number of days after applying (0 — don't remind), e.g.:\n/followup 7",
+		"followup.error":            "Failed to save the setting",
+		"followup.saved":            "Follow-up reminder after applying: in %s.",
+		"tz.usage":                  "Specify an IANA time zone, e.g.:\n/tz Europe/Berlin",
+		"tz.error":                  "Failed to save the time zone",
+		"tz.saved":                  "Time zone saved: %s",
+		"thread.off":                "Messages will go to the main chat.",
+		"thread.usage":              "Specify a forum topic ID (the last number in the topic link) or off, e.g.:\n/thread 123",
+		"thread.error":              "Failed to save the topic",
+		"thread.saved":              "Vacancies will go to topic %s",
+		"settings.not_set":          "not set",
+		"settings.not_used":         "not used",
+		"settings.default_interval": "default (%s)",
+		"settings.text":             "📌 *Your settings:*\n🔖 Tags: `%s`\n🏙️ Cities: `%s`\n⏱️ Interval: `%s`\n📰 Sources: `%s`\n🎯 Scoring words: `%s`\n🧰 Skills: `%s`\n💰 Min. salary: `%s`\n📊 Threshold: `%s`\n💱 Salary currency: `%s`\n🌐 Language: `%s`",
+		"pause.error":               "❌ Failed to pause.",
+		"pause.done":                "⏸️ Vacancy search paused. To continue — /search.",
+		"search_add.example":        "Example: /search_add Go in Europe | golang,go | Berlin,Amsterdam",
+		"search_add.saved":          "✅ Search saved:\n%s\n\nChoose sources with /sources %s",
+		"search_del.usage":          "Specify a search ID from /searches. The main search can't be deleted — it's set by /tags and /city",
+		"search_del.not_found":      "❌ Search %s not found.",
+		"search_del.done":           "🗑 Search %s deleted.",
+		"sources.saved":             "📰 Sources of search “%s”: %s\nAvailable: %s\nChange: /sources %s %s",
+		"channel_add.example":       "Example: /channel_add email me@example.com",
+		"channel_add.added":         "✅ Channel %s added: %s %s",
+		"channel_add.secret":        "🔑 Webhook signing secret: %s\nHeader X-Signature = sha256=HMAC-SHA256(secret, X-Timestamp + \".\" + body)",
+		"channel_add.route":         "Enable: /route telegram,%s",
+		"channel_del.usage":         "Specify a channel ID from /channels. The Telegram chat can't be deleted — remove it from /route",
+		"channel_del.not_found":     "❌ Channel %s not found.",
+		"channel_del.done":          "🗑 Channel %s deleted.",
+		"route.example":             "Example: /route telegram,a1b2c3",
+		"route.saved":               "📬 Channels %s saved.",
+		"search.error":              "❌ Failed to resume the search.",
+		"search.already":            "🔄 The search is already active.",
+		"search.resumed":            "✅ Search resumed.",
+
+		// Закладки и воронка
+		"saved.delete":    "🗑 Delete",
+		"saved.status":    "Status: %s",
+		"saved.error":     "❌ Failed to get saved vacancies.",
+		"saved.empty":     "No saved vacancies. Tap 📌 under a vacancy card or use /save <id>",
+		"saved.limited":   "Showing the latest %d of %d. Full picture — /pipeline",
+		"pipeline.error":  "❌ Failed to get the pipeline.",
+		"pipeline.empty":  "The pipeline is empty. Save a vacancy with the 📌 button or /save <id>",
+		"pipeline.title":  "📊 Application pipeline:",
+		"pipeline.footer": "Change status: /saved, add a note: /note <id> text",
+
+		// Поиски
+		"search.main":             "Main",
+		"search.load_failed":      "failed to load searches",
+		"search.save_failed":      "failed to save the search",
+		"search.too_many":         "you can save at most %d searches",
+		"search.main_channels":    "channels of the main search are set with /route without a search ID",
+		"search.name_and_tags":    "specify a name and tags",
+		"search.unknown_sources":  "unknown sources: %s. Available: %s",
+		"search.not_found":        "search %s not found, see /searches",
+		"searches.title":          "🔎 Your searches:",
+		"searches.footer":         "Add: /search_add Name | tags | cities\nDelete: /search_del <id>\nSources: /sources <id> %s",
+		"searches.default_cities": "default",
+		"searches.item":           "%s (%s)\n🔖 Tags: %s\n🏙️ Cities: %s\n📰 Sources: %s",
+
+		// Каналы
+		"card.title":                   "❤ *New vacancy:* [%s](%s)",
+		"channel.not_found":            "channel %s not found, see /channels",
+		"channel_add.kind_and_address": "specify the channel kind and address",
+		"channel_add.telegram":         "this chat is already connected as the telegram channel",
+		"channel_add.unavailable":      "channel %q is unavailable. Available: %s",
+		"channel_add.too_many":         "you can add at most %d channels",
+		"channel_add.save_failed":      "failed to save the channel",
+		"channels.load_failed":         "failed to load channels",
+		"route.no_channels":            "list channels separated by commas",
+		"route.save_failed":            "failed to save channels",
+		"route.default":                "by default",
+		"route.search":                 "of search “%s”",
+		"channels.error":               "❌ Failed to get the channel list.",
+		"channels.title":               "📬 Delivery channels:",
+		"channels.this_chat":           "telegram — this chat",
+		"channels.default":             "Default: %s",
+		"channels.search":              "Search “%s”: %s",
+		"channels.footer":              "Add: /channel_add <kind> <address>, kinds: %s\nDelete: /channel_del <id>\nWhere to send: /route telegram,<id> or /route <search id> <channel ids>",
+
+		// Кнопки
+		"button.track":            "⭐ Follow",
+		"button.save":             "📌 Save",
+		"callback.admins_only":    "🔒 Chat administrators only",
+		"callback.track_error":    "❌ Failed to load the vacancy",
+		"callback.tracking":       "⭐ Following “%s”",
+		"callback.save_error":     "❌ Failed to save the vacancy",
+		"callback.not_saved":      "❌ The vacancy isn't among saved ones",
+		"callback.unsave_error":   "❌ Failed to delete",
+		"callback.unsaved":        "🗑 Removed from saved",
+		"callback.employer_error": "❌ Failed to load the employer",
+
+		// Проверка вакансий
+		"check.nothing_found": "🔍 No new vacancies found.",
+
+		// Трекер и работодатели
+		"tracker.changes":           "🔔 Changes in vacancy “%s”",
+		"watch_employer.save_error": "❌ Failed to save the employer.",
+		"watch_employer.done":       "🏢 Following vacancies of “%s” (ID %s). List: /employers",
+
+		// Разовый поиск
+		"find.sort.d":      "🕓 By date",
+		"find.sort.s":      "💰 By salary",
+		"find.sort.r":      "🎯 By relevance",
+		"find.usage":       "Enter a query after the command, e.g.:\n/find golang Moscow",
+		"find.save_error":  "❌ Failed to save the query.",
+		"find.error":       "❌ Failed to search hh.ru.",
+		"find.expired":     "The search has expired, run /find again",
+		"find.unavailable": "❌ hh.ru is unavailable",
+		"find.nothing":     "Nothing found.",
+		"find.found":       "%s — found %s",
+
+		// Рынок
+		"market.example":       "Example: /market golang Moscow 30d",
+		"market.load_error":    "❌ Failed to load market statistics.",
+		"market.chart_caption": "Bars — new vacancies per day, line — median net salary, thousand ₽",
+
+		// История
+		"layout.short_datetime":  "01-02 15:04",
+		"history.employers":      "Employers",
+		"history.action.tracked": "⭐ Tracked",
+		"history.action.saved":   "📌 Saved",
+		"history.action.unsaved": "🗑 Removed from saved",
+		"history.action.note":    "📝 Note",
+		"history.load_error":     "❌ Failed to load the history.",
+		"history.empty":          "The history is empty. Vacancies the bot sent over the last %s will appear here.",
+		"history.title":          "🗂 Sent vacancies: %d",
+		"history.truncated":      "Showing the latest %d. Narrow it down: /history <word> or export everything: /export csv",
+		"export.empty":           "The history is empty — nothing to export.",
+		"export.sheet":           "History",
+		"export.usage":           "Format: /export %s",
+		"export.error":           "❌ Failed to export the history.",
+		"export.column.sent":     "Sent",
+		"export.column.vacancy":  "Vacancy",
+		"export.column.employer": "Company",
+		"export.column.city":     "City",
+		"export.column.salary":   "Salary",
+		"export.column.score":    "Score",
+		"export.column.source":   "Source",
+		"export.column.search":   "Search",
+		"export.column.url":      "Link",
+		"export.column.actions":  "Actions",
+
+		// Персональные данные и напоминания
+		"mydata.caption":          "🗄 Everything the bot stores about this chat: settings, searches, history, bookmarks, notes and reminders. To delete it — /deleteme",
+		"mydata.user_caption":     "🗄 Data of user %d. To delete it: /user %d delete",
+		"mydata.error":            "❌ Failed to collect the data, please try again later.",
+		"deleteme.confirm":        "⚠️ Delete all data of this chat?\n\nSettings, searches, channels, sent vacancy history, bookmarks, notes, reminders, feed and API tokens will be deleted, and vacancy delivery will stop. This cannot be undone — save an archive first if you need it: /mydata",
+		"deleteme.button.all":     "🗑 Delete everything",
+		"deleteme.button.cancel":  "❌ Cancel",
+		"deleteme.cancelled":      "Deletion cancelled",
+		"deleteme.error":          "❌ Failed to delete the data",
+		"deleteme.deleted":        "🗑 Data deleted",
+		"deleteme.done":           "🗑 All data of this chat has been deleted and delivery stopped. To start over — /start",
+		"deleteuser.confirm":      "⚠️ Delete all data of user %d and stop their delivery? This cannot be undone.",
+		"deleteuser.button":       "🗑 Delete",
+		"deleteuser.done":         "🗑 Data of user %d deleted, delivery stopped.",
+		"followup.fired":          "⏰ %s\nhttps://hh.ru/vacancy/%s\n%s\n\nChange the status: /saved",
+		"followup.text":           "No reply for %s — follow up with the employer?",
+		"remind.fired":            "⏰ Reminder",
+		"remind.not_enough_args":  "not enough arguments",
+		"remind.bad_vacancy":      "could not recognize vacancy %q",
+		"remind.bad_date":         "the date must be in YYYY-MM-DD HH:MM format",
+		"remind.past":             "this time has already passed",
+		"reminders.load_error":    "❌ Failed to load reminders.",
+		"reminders.empty":         "No reminders scheduled.",
+		"reminders.title":         "⏰ Reminders:",
+		"reminders.item":          "%s — %s, vacancy %s",
+		"reminders.kind.remind":   "reminder",
+		"reminders.kind.followup": "no reply",
+
+		// Ленты и API
+		"feed.title":                "Vacancies: %s",
+		"feed.description":          "Tags: %s",
+		"feed.employer":             "Company: %s",
+		"feed.city":                 "City: %s",
+		"feed.salary":               "Salary: %s",
+		"feed.score":                "Score: %d",
+		"feed.source":               "Source: %s",
+		"feeds.load_error":          "❌ Failed to load the feed token.",
+		"feeds.save_error":          "❌ Failed to issue a feed token.",
+		"feeds.reset":               "🔄 The token has been replaced, old feed URLs no longer work.",
+		"feeds.title":               "📡 Feeds of your searches (RSS, Atom, JSON Feed):",
+		"feeds.no_public_url":       "The server's public URL is not configured (PUBLIC_URL) — prepend the bot's address to the paths yourself.",
+		"feeds.footer":              "A feed holds the latest vacancies sent for the search. The URL contains a secret token: do not share it. To revoke — /feed_reset",
+		"apitoken.none":             "There is no active API token.",
+		"apitoken.revoked":          "🔒 API token revoked.",
+		"apitoken.error":            "❌ Failed to issue an API token.",
+		"apitoken.base_placeholder": "<bot address>",
+		"apitoken.issued":           "🔑 API token (shown once, the previous one no longer works):\n%s\n\nExample: curl -H \"Authorization: Bearer %s\" %s/api/v1/searches\nRevoke: /apitoken revoke",
+
+		// Администрирование
+		"broadcast.usage":         "Enter the broadcast text after the command, e.g.:\n/broadcast The bot will be unavailable from 02:00 to 03:00",
+		"broadcast.prepare_error": "❌ Failed to prepare the broadcast.",
+		"broadcast.preview":       "📣 Broadcast preview (%s):\n\n%s",
+		"broadcast.button.send":   "✅ Send",
+		"broadcast.not_found":     "The broadcast was not found or has already been sent",
+		"broadcast.cancelled":     "Broadcast cancelled",
+		"broadcast.started":       "📣 Broadcast started",
+		"broadcast.users_error":   "❌ Failed to load the user list.",
+		"broadcast.done":          "📣 Broadcast finished: delivered %d, blocked the bot %d, errors %d",
+		"user.usage":              "Specify the user's chatID, e.g.:\n/user 123456789\n/user 123456789 reset\n/user 123456789 export\n/user 123456789 delete",
+		"user.bad_id":             "❌ Invalid chatID",
+		"user.unknown_action":     "Unknown action, available: reset, export, delete",
+		"user.reset_done":         "♻ User %d has been reset, checker restarted.",
+		"user.info":               "👤 User %d\n🔖 Tags: %s\n🏙️ Cities: %s\n⏱️ Interval: %s\n⏸️ Paused: %t, disabled: %t, checker running: %t\n🕓 Last check: %s\n⭐ Tracked vacancies: %d, 📌 saved: %d, 🏢 employers: %d\n\nReset state: /user %d reset\nExport data: /user %d export, delete: /user %d delete",
+		"maintenance.on_error":    "❌ Failed to enable maintenance mode.",
+		"maintenance.on":          "🛠 Maintenance mode is on: all vacancy checks are paused. To resume — /resumeall",
+		"maintenance.off_error":   "❌ Failed to disable maintenance mode.",
+		"maintenance.off":         "✅ Maintenance mode is off, checks resumed.",
+		"stats.maintenance_on":    "on",
+		"stats.maintenance_off":   "off",
+		"stats.text":              "📈 Bot statistics\n👥 Users: %d (paused: %d, disabled: %d)\n▶ Active checkers: %d, employers: %d\n🔍 Checks in the last hour: %d\n📨 Vacancies sent in the last day: %d\n🌐 hh.ru requests in the last day: %d, errors: %d (%.1f%%)\n🛠 Maintenance mode: %s",
+
+		// Веб-кабинет
+		"web.login":                         "Sign in",
+		"web.login.text":                    "Sign in with Telegram to manage your searches, vacancy history and bookmarks.",
+		"web.login.domain_hint":             "The sign-in button works only if the site's domain is set for the bot via /setdomain in @BotFather.",
+		"web.login.disabled":                "the user is disabled",
+		"web.login.session_error":           "failed to open a session",
+		"web.login.no_hash":                 "no Telegram signature",
+		"web.login.bad_hash":                "the Telegram signature does not match",
+		"web.login.expired":                 "the sign-in has expired, please sign in again",
+		"web.login.no_id":                   "no Telegram user ID",
+		"web.bad_form":                      "invalid form, please reload the page",
+		"web.logout":                        "Sign out",
+		"web.save":                          "Save",
+		"web.add":                           "Add",
+		"web.delete":                        "Delete",
+		"web.searches":                      "Searches",
+		"web.searches.name":                 "Name",
+		"web.searches.name_placeholder":     "Go in Europe",
+		"web.searches.main_hint":            "Main search: the same tags and cities as in /tags and /city. Empty tags mean the default query.",
+		"web.searches.tags":                 "Comma-separated tags",
+		"web.searches.cities":               "Comma-separated cities",
+		"web.searches.cities_default":       "default",
+		"web.searches.cities_placeholder":   "Berlin, Amsterdam",
+		"web.searches.sources_hint":         "Sources (none selected — hh.ru only):",
+		"web.searches.channels_hint":        "Delivery channels (none selected — default channels from /route):",
+		"web.searches.sources":              "Sources:",
+		"web.searches.channels":             "Delivery channels:",
+		"web.searches.delete_confirm":       "Delete search “%s”?",
+		"web.searches.new":                  "New search",
+		"web.searches.this_chat":            "Telegram — this chat",
+		"web.searches.created":              "Search “%s” saved",
+		"web.searches.updated":              "Search “%s” updated",
+		"web.searches.deleted":              "Search deleted",
+		"web.searches.not_found":            "search not found",
+		"web.searches.main_delete":          "the main search cannot be deleted",
+		"web.history":                       "History",
+		"web.history.title":                 "Match history",
+		"web.history.all_searches":          "All searches",
+		"web.history.all_sources":           "All sources",
+		"web.history.all_time":              "All time",
+		"web.history.day":                   "Last day",
+		"web.history.week":                  "Last week",
+		"web.history.month":                 "Last month",
+		"web.history.query_placeholder":     "Title, company or city",
+		"web.history.show":                  "Show",
+		"web.history.vacancy":               "Vacancy",
+		"web.history.salary":                "Salary",
+		"web.history.search":                "Search",
+		"web.history.found":                 "Found",
+		"web.history.bookmark":              "Bookmark",
+		"web.history.empty":                 "Nothing found. The history holds vacancies sent for your searches.",
+		"web.bookmarks":                     "Bookmarks",
+		"web.bookmarks.vacancy_placeholder": "hh.ru vacancy ID or link",
+		"web.bookmarks.saved_at":            "saved %s",
+		"web.bookmarks.change":              "Change",
+		"web.bookmarks.note":                "Note",
+		"web.bookmarks.load_error":          "failed to load bookmarks",
+		"web.bookmarks.no_vacancy":          "enter an hh.ru vacancy ID or link",
+		"web.bookmarks.save_error":          "failed to save vacancy %s",
+		"web.bookmarks.saved":               "Saved: %s",
+		"web.bookmarks.bad_status":          "unknown status",
+		"web.bookmarks.not_found":           "bookmark not found",
+		"web.bookmarks.status_changed":      "Status changed: %s",
+		"web.bookmarks.empty_note":          "the note is empty",
+		"web.bookmarks.note_added":          "Note added",
+		"web.bookmarks.delete_error":        "failed to delete the bookmark",
+		"web.bookmarks.deleted":             "Bookmark deleted",
+
+		// Подтверждение каналов
+		"channel.private_webhook":    "the webhook points to a local or internal address",
+		"channel.confirm_subject":    "hhruBot confirmation code",
+		"channel.confirm_body":       "Your address confirmation code: %s\n\nSend the bot /channel_confirm <channel id> <code> within an hour. If you did not add this address, just ignore this message — no vacancies will be sent here without confirmation.",
+		"channel_add.code_throttled": "a confirmation code was sent less than a minute ago, please try again later",
+		"channel_add.code_failed":    "failed to send the confirmation code, check the address",
+		"channel_add.confirm":        "📧 A code has been sent to %s. Confirm the address: /channel_confirm %s <code> — until then no vacancies will be sent there.",
+		"channel_confirm.usage":      "specify the channel ID and code: /channel_confirm <id> <code>",
+		"channel_confirm.failed":     "failed to confirm the channel",
+		"channel_confirm.bad_code":   "wrong or expired code",
+		"channel_confirm.attempts":   "too many wrong attempts, the code was reset — remove the channel (/channel_del %s) and add it again to get a new code",
+		"channel_confirm.done":       "✅ Channel %s confirmed.",
+		"channels.pending":           "(not confirmed: /channel_confirm %s <code>)",
+
+		// Ошибки HTTP API
+		"api.no_token":       "an Authorization: Bearer <token from /apitoken> header is required",
+		"api.bad_token":      "invalid token",
+		"api.disabled":       "user is disabled",
+		"api.main_rename":    "the main search cannot be renamed",
+		"api.main_delete":    "the main search cannot be deleted",
+		"api.not_found":      "search not found",
+		"api.history_error":  "failed to load history",
+		"api.pause_error":    "failed to pause",
+		"api.resume_error":   "failed to resume search",
+		"api.pipeline_error": "failed to load the pipeline",
+		"api.bad_json":       "invalid JSON: %s",
+		"api.internal":       "internal error",
+
+		// Секреты в группах
+		"private.for_chat":    "For the chat “%s”:",
+		"private.sent":        "🔒 Sent privately to whoever ran the command.",
+		"private.failed":      "🔒 Could not message you privately: open a chat with the bot, press Start and run the command again.",
+		"private.unavailable": "🔒 This command sends secrets in a private message, but the bot cannot see who posted in a channel. Run it in a private chat with the bot or in a group.",
+
+		// ID вакансий
+		"vacancy_id.foreign": "%s is not an hh.ru vacancy. Tracking, bookmarks, notes and reminders work only for hh.ru vacancies: give a number or a https://hh.ru/vacancy/… link",
+	},
+}
@@ -0,0 +1,149 @@
+// Локализация: каталоги сообщений на русском и английском с правилами
+// множественного числа
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Lang — язык сообщений пользователю
+type Lang string
+
+const (
+	RU Lang = "ru"
+	EN Lang = "en"
+
+	// Default — язык, если пользователь его не выбрал, а Telegram не сообщил
+	Default = RU
+)
+
+// Langs — поддерживаемые языки в порядке показа
+var Langs = []Lang{RU, EN}
+
+// Names — названия языков на них самих
+var Names = map[Lang]string{
+	RU: "🇷🇺 Русский",
+	EN: "🇬🇧 English",
+}
+
+// catalog — переводы одного языка. Формы множественного числа перечислены
+// в порядке правила языка: для русского — «1 минута», «2 минуты», «5 минут».
+type catalog struct {
+	messages map[string]string
+	plurals  map[string][]string
+	plural   func(n int) int
+}
+
+var catalogs = map[Lang]catalog{
+	RU: ru,
+	EN: en,
+}
+
+// Parse разбирает код языка: "en", "EN", "en-US" → EN
+func Parse(code string) (Lang, bool) {
+	code = strings.ToLower(strings.TrimSpace(code))
+	if base, _, ok := strings.Cut(code, "-"); ok {
+		code = base
+	}
+	if _, ok := catalogs[Lang(code)]; !ok {
+		return "", false
+	}
+	return Lang(code), true
+}
+
+// FromTelegram выбирает язык по language_code из профиля Telegram. Русский
+// понимают и в соседних странах, остальным отвечаем по-английски.
+func FromTelegram(code string) Lang {
+	if code == "" {
+		return Default
+	}
+	if l, ok := Parse(code); ok {
+		return l
+	}
+	switch base, _, _ := strings.Cut(strings.ToLower(code), "-"); base {
+	case "uk", "be", "kk", "ky", "uz", "hy", "az", "tg":
+		return RU
+	}
+	return EN
+}
+
+// T возвращает сообщение по ключу, подставляя аргументы как fmt.Sprintf.
+// Если перевода нет, берётся язык по умолчанию, а если нет и его — сам ключ.
+func (l Lang) T(key string, args ...any) string {
+	format, ok := catalogs[l].messages[key]
+	if !ok {
+		if format, ok = catalogs[Default].messages[key]; !ok {
+			format = key
+		}
+	}
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// N возвращает форму сообщения для числа n: l.N("minutes", 5) → «5 минут»
+func (l Lang) N(key string, n int) string {
+	c := catalogs[l]
+	forms, ok := c.plurals[key]
+	if !ok {
+		c = catalogs[Default]
+		if forms, ok = c.plurals[key]; !ok {
+			return fmt.Sprintf("%d %s", n, key)
+		}
+	}
+	i := c.plural(n)
+	if i >= len(forms) {
+		i = len(forms) - 1
+	}
+	return fmt.Sprintf(forms[i], n)
+}
+
+// Err возвращает текст ошибки на языке l: переводит ошибки из Errorf,
+// остальные показывает как есть
+func (l Lang) Err(err error) string {
+	var e *Error
+	if errors.As(err, &e) {
+		return l.T(e.Key, e.Args...)
+	}
+	return err.Error()
+}
+
+// Error — ошибка, текст которой показывается пользователю на его языке
+type Error struct {
+	Key  string
+	Args []any
+}
+
+// Errorf создаёт ошибку с текстом из каталога
+func Errorf(key string, args ...any) error {
+	return &Error{Key: key, Args: args}
+}
+
+func (e *Error) Error() string {
+	return Default.T(e.Key, e.Args...)
+}
+
+// pluralRU: 1, 21, 101 — первая форма; 2–4, 22–24 — вторая; остальные — третья
+func pluralRU(n int) int {
+	if n < 0 {
+		n = -n
+	}
+	switch {
+	case n%10 == 1 && n%100 != 11:
+		return 0
+	case n%10 >= 2 && n%10 <= 4 && (n%100 < 12 || n%100 > 14):
+		return 1
+	}
+	return 2
+}
+
+// pluralEN: 1 — первая форма, остальные — вторая
+func pluralEN(n int) int {
+	if n == 1 || n == -1 {
+		return 0
+	}
+	return 1
+}
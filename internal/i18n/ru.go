@@ -0,0 +1,494 @@
+package i18n
+
+var ru = catalog{
+	plural: pluralRU,
+	plurals: map[string][]string{
+		"minutes":            {"%d минута", "%d минуты", "%d минут"},
+		"days":               {"%d день", "%d дня", "%d дней"},
+		"market.with_salary": {"%d вакансия с зарплатой", "%d вакансии с зарплатой", "%d вакансий с зарплатой"},
+		"vacancies":          {"%d вакансия", "%d вакансии", "%d вакансий"},
+		"recipients":         {"%d получатель", "%d получателя", "%d получателей"},
+	},
+	messages: map[string]string{
+		// Зарплаты
+		"salary.from":  "от %s",
+		"salary.to":    "до %s",
+		"salary.net":   "на руки",
+		"salary.gross": "до вычета налогов",
+		"salary.none":  "не указана",
+
+		// Оценка вакансий
+		"score.bad_weight":   "некорректный вес у %q",
+		"score.empty_term":   "пустое слово в %q",
+		"score.in_title":     "%s в названии",
+		"score.in_snippet":   "%s в описании",
+		"score.unwanted":     "нежелательное %s",
+		"score.skill":        "навык %s",
+		"score.salary":       "зарплата %s",
+		"score.salary_below": "зарплата ниже %d",
+
+		// Изменения отслеживаемых вакансий
+		"tracking.description_changed": "описание существенно изменено",
+		"tracking.archived":            "вакансия перенесена в архив",
+		"tracking.reopened":            "вакансия снова открыта",
+		"tracking.kind.salary":         "💰 Зарплата",
+		"tracking.kind.description":    "📝 Описание",
+		"tracking.kind.archived":       "📦 Архив",
+		"tracking.kind.reopened":       "🔓 Открыта снова",
+
+		// Воронка откликов
+		"pipeline.saved":     "📌 Сохранено",
+		"pipeline.applied":   "📨 Отклик",
+		"pipeline.interview": "🗣 Собеседование",
+		"pipeline.offer":     "🎉 Оффер",
+		"pipeline.rejected":  "🚫 Отказ",
+
+		// Рынок
+		"market.no_tag":        "укажите тег",
+		"market.bad_period":    "период — от 1d до %dd",
+		"market.all_cities":    "все города",
+		"market.title":         "📊 Рынок: %s, %s, за %s",
+		"market.no_data":       "Данных пока нет. Статистика копится по запросам, которые бот проверяет для пользователей: добавьте тег в /tags или /search_add и загляните позже.",
+		"market.total":         "🆕 Новых вакансий: %d (в среднем %.1f в день)",
+		"market.salaries":      "💰 Зарплаты на руки (%s):",
+		"market.percentiles":   "   25%% — %s, медиана — %s\n   75%% — %s, 90%% — %s",
+		"market.no_salaries":   "💰 Зарплаты не указаны ни в одной вакансии",
+		"market.top_employers": "🏢 Больше всего вакансий:",
+		"market.top_skills":    "🧠 Ключевые навыки (по вакансиям с полным описанием):",
+		"market.thousand_rub":  "%.0f тыс. ₽",
+
+		// Источники вакансий
+		"source.hh":       "hh.ru",
+		"source.superjob": "SuperJob",
+		"source.trudvsem": "Работа России",
+		"source.rss":      "RSS-ленты",
+
+		// Каналы доставки
+		"channel.telegram":    "Telegram",
+		"channel.email":       "Почта",
+		"channel.slack":       "Slack",
+		"channel.discord":     "Discord",
+		"channel.matrix":      "Matrix",
+		"channel.webhook":     "Вебхук",
+		"channel.bad_email":   "некорректный адрес почты",
+		"channel.bad_webhook": "нужен адрес вебхука вида https://…",
+		"channel.bad_matrix":  "нужен ID комнаты вида !abcdef:matrix.org",
+		"channel.unknown":     "неизвестный канал %q",
+
+		// Карточка вакансии
+		"card.employer":     "Компания: %s",
+		"card.area":         "Город: %s",
+		"card.other_areas":  "Также в: %s",
+		"card.salary":       "Зарплата: %s",
+		"card.score":        "Балл: %d",
+		"card.source":       "Источник: %s",
+		"card.search":       "Поиск: %s",
+		"card.subject_one":  "Новая вакансия: %s",
+		"card.subject_many": "Новые вакансии: %d",
+
+		// Язык
+		"lang.usage":   "Укажите язык: /lang ru, /lang en или /lang auto — как в настройках Telegram",
+		"lang.current": "🌐 Язык бота: %s\nВыберите другой или «Как в Telegram», чтобы следовать языку приложения.",
+		"lang.saved":   "🌐 Язык бота: %s",
+		"lang.auto":    "Как в Telegram",
+
+		// Команды
+		"layout.datetime":           "02.01.2006 15:04",
+		"layout.datetime_at":        "02.01.2006 в 15:04",
+		"layout.date":               "02.01.2006",
+		"unknown_command":           "Неизвестная команда. Попробуйте /start",
+		"group.admins_only":         "🔒 Менять настройки бота в этом чате могут только администраторы.",
+		"start.group":               "👋 Бот подключён к чату «%s».\n\nНовые вакансии будут приходить сюда. Настраивать поиск могут только администраторы чата:\n/tags golang,devops — ключевые слова\n/city Москва — город(а)\n/thread 123 — писать в тему форума (ID темы из ссылки на неё)\n/settings — текущие настройки\n/lang en — язык бота\n/help — справка по командам",
+		"start.private":             "👋 Добро пожаловать в HH.ru Бот!\n\nЯ помогу тебе следить за новыми вакансиями.\n\n⚙️ Основные команды:\n/find golang Москва — найти вакансии прямо сейчас\n/tags golang,devops — задать ключевые слова\n/city Москва — выбрать город(а)\n/interval 30 — интервал проверки (в минутах)\n/keywords golang:3,-1с:5 — слова для оценки вакансий\n/skills go,docker — ключевые навыки\n/minsalary 200000 — минимальная зарплата\n/threshold 3 — минимальный балл для отправки\n/currency USD — валюта для зарплат\n/salary_basis net — зарплата на руки (net) или до налогов (gross)\n/track 123456 — следить за изменениями вакансии\n/watch_employer Яндекс — все новые вакансии компании\n/saved — сохранённые вакансии, /pipeline — воронка откликов\n/remind 123456 2026-10-21 14:00 — напоминание о собеседовании\n/tracked — отслеживаемые вакансии\n/pause — приостановить уведомления\n/search — возобновить работу\n/settings — показать текущие настройки\n/lang en — switch to English\n/help — справка по командам",
+		"help":                      "🛠 Доступные команды:\n/find — разовый поиск с листанием страниц: /find <запрос> [город]\n/tags — задать ключевые слова\n/city — выбрать города\n/searches — все поиски: основной и сохранённые\n/search_add — ещё один поиск: /search_add Название | теги | города\n/search_del — удалить сохранённый поиск\n/sources — источники поиска: hh.ru, SuperJob, Работа России, RSS-ленты\n/channels — каналы доставки: почта, Slack, Discord, Matrix, вебхук\n/channel_add — добавить канал: /channel_add slack https://hooks.slack.com/…\n/channel_confirm — подтвердить почту кодом из письма\n/channel_del — удалить канал\n/route — куда слать вакансии: /route [id поиска] telegram,<id канала>\n/history — отправленные вакансии, поиск: /history яндекс\n/export — выгрузить историю файлом: /export csv, json или xlsx\n/market — рынок по тегу: вакансии, зарплаты, работодатели и навыки, пример: /market golang Москва 30d\n/feed — ленты поисков в RSS, Atom и JSON Feed для читалок и автоматизаций\n/feed_reset — выдать новый токен лент, старые адреса перестанут работать\n/apitoken — токен HTTP API для скриптов (/apitoken revoke — отозвать)\n/interval — частота поиска (в минутах)\n/keywords — слова с весами для оценки (минус — нежелательные, off — сбросить)\n/skills — ключевые навыки с весами (off — сбросить)\n/minsalary — минимальная зарплата\n/threshold — минимальный балл вакансии\n/currency — валюта, в которой показывать зарплаты\n/salary_basis — net или gross\n/track — следить за вакансией (или ⭐ под карточкой)\n/untrack — перестать следить\n/tracked — список отслеживаемых вакансий\n/changes — история изменений вакансии\n/watch_employer — следить за всеми вакансиями компании\n/unwatch_employer — перестать следить за компанией\n/employers — отслеживаемые компании\n/employer_interval — интервал проверки компаний (в минутах)\n/save — сохранить вакансию (или 📌 под карточкой)\n/saved — сохранённые вакансии с кнопками смены статуса\n/pipeline — воронка: сохранено → отклик → собеседование → оффер → отказ\n/note — заметка к сохранённой вакансии\n/remind — напоминание: /remind <вакансия> ГГГГ-ММ-ДД ЧЧ:ММ [текст]\n/reminders — запланированные напоминания\n/unremind — отменить напоминание\n/followup — через сколько дней после отклика напомнить (0 — не напоминать)\n/tz — часовой пояс, например Europe/Moscow\n/thread — тема форума для вакансий в группе (off — основной чат)\n/lang — язык бота: русский или английский\n/pause — остановить рассылку\n/search — возобновить рассылку\n/settings — показать текущие настройки\n/mydata — архив всех данных, которые бот хранит о чате\n/deleteme — удалить все данные чата\n/help — показать справку",
+		"tags.usage":                "Введите ключевые слова после команды, пример:\n/tags golang,devops",
+		"tags.error":                "Ошибка при сохранении тегов",
+		"tags.saved":                "Теги сохранены: %s",
+		"city.usage":                "Введите города после команды, пример:\n/city Москва,Санкт-Петербург",
+		"city.error":                "Ошибка при сохранении городов",
+		"city.saved":                "Города сохранены: %s",
+		"interval.usage":            "Интервал должен быть положительным числом в минутах, пример:\n/interval 30",
+		"interval.min":              "⚠ Минимальный интервал — %s.",
+		"interval.error":            "Ошибка при сохранении интервала",
+		"interval.saved":            "Интервал сохранён: %s.",
+		"keywords.usage":            "Введите слова с весами после команды, минус — нежелательное слово, пример:\n/keywords golang:3, kubernetes, -1с:5\nСбросить: /keywords off",
+		"keywords.error":            "Ошибка при сохранении ключевых слов",
+		"keywords.cleared":          "Ключевые слова сброшены, оценка по ним отключена.",
+		"keywords.saved":            "Ключевые слова для оценки сохранены: %s",
+		"skills.usage":              "Введите ключевые навыки после команды, пример:\n/skills go, docker:3, postgresql\nСбросить: /skills off",
+		"skills.error":              "Ошибка при сохранении навыков",
+		"skills.cleared":            "Навыки сброшены, оценка по ним отключена.",
+		"skills.saved":              "Навыки сохранены: %s",
+		"minsalary.usage":           "Минимальная зарплата должна быть числом (0 — не учитывать), пример:\n/minsalary 200000",
+		"minsalary.error":           "Ошибка при сохранении зарплаты",
+		"minsalary.saved":           "Минимальная зарплата сохранена: %s",
+		"threshold.usage":           "Порог должен быть целым числом, пример:\n/threshold 3",
+		"threshold.error":           "Ошибка при сохранении порога",
+		"threshold.saved":           "Порог релевантности сохранён: %s",
+		"currency.usage":            "Введите код валюты после команды, пример:\n/currency USD",
+		"currency.unknown":          "❌ Неизвестная валюта: %s",
+		"currency.error":            "Ошибка при сохранении валюты",
+		"currency.saved":            "Зарплаты будут показаны в валюте: %s",
+		"salary_basis.usage":        "Укажите net (на руки) или gross (до вычета налогов), пример:\n/salary_basis net",
+		"salary_basis.error":        "Ошибка при сохранении базиса зарплаты",
+		"salary_basis.saved":        "Базис зарплаты сохранён: %s",
+		"tracked.error":             "❌ Не удалось получить отслеживаемые вакансии.",
+		"tracked.empty":             "Вы пока не следите ни за одной вакансией. Нажмите ⭐ под вакансией или используйте /track <id>",
+		"tracked.title":             "⭐ Отслеживаемые вакансии:",
+		"tracked.archived":          " (в архиве)",
+		"tracked.line":              "%s — %s%s, зарплата: %s",
+		"tracked.footer":            "История изменений: /changes <id>",
+		"untrack.usage":             "Укажите ID или ссылку на вакансию, пример:\n/untrack 123456",
+		"untrack.error":             "❌ Не удалось прекратить отслеживание.",
+		"untrack.done":              "Больше не слежу за вакансией %s",
+		"track.usage":               "Укажите ID или ссылку на вакансию, пример:\n/track https://hh.ru/vacancy/123456",
+		"track.error":               "❌ Не удалось загрузить вакансию %s",
+		"track.done":                "⭐ Слежу за вакансией «%s». Сообщу об изменениях зарплаты, описания и архивации.",
+		"changes.usage":             "Укажите ID вакансии, пример:\n/changes 123456",
+		"changes.error":             "❌ Не удалось получить историю изменений.",
+		"changes.empty":             "Изменений по вакансии %s пока не было.",
+		"changes.title":             "🕓 История изменений вакансии %s:",
+		"watch_employer.usage":      "Введите название компании после команды, пример:\n/watch_employer Яндекс",
+		"watch_employer.error":      "❌ Не удалось выполнить поиск работодателя.",
+		"watch_employer.not_found":  "Работодатели с открытыми вакансиями по запросу «%s» не найдены.",
+		"watch_employer.choose":     "Найдено несколько работодателей, выберите нужного:",
+		"unwatch_employer.usage":    "Укажите ID работодателя из /employers, пример:\n/unwatch_employer 1740",
+		"unwatch_employer.error":    "❌ Не удалось удалить работодателя.",
+		"unwatch_employer.done":     "Больше не слежу за работодателем %s",
+		"employers.error":           "❌ Не удалось получить список работодателей.",
+		"employers.empty":           "Вы не следите ни за одним работодателем. Добавить: /watch_employer Яндекс",
+		"employers.title":           "🏢 Отслеживаемые работодатели:",
+		"employers.footer":          "Удалить: /unwatch_employer <id>",
+		"employer_interval.usage":   "Интервал должен быть числом минут не меньше %d, пример:\n/employer_interval 60",
+		"employer_interval.saved":   "Интервал проверки работодателей сохранён: %s.",
+		"save.usage":                "Укажите ID или ссылку на вакансию, пример:\n/save 123456",
+		"save.error":                "❌ Не удалось сохранить вакансию %s",
+		"note.usage":                "Укажите ID сохранённой вакансии и текст заметки, пример:\n/note 123456 созвон с HR во вторник",
+		"note.not_found":            "❌ Вакансия не найдена в сохранённых. Сначала сохраните её: /save <id>",
+		"note.added":                "🗒 Заметка добавлена.",
+		"remind.example":            "Пример: /remind 123456 2026-10-21 14:00 собеседование в Zoom",
+		"remind.scheduled":          "⏰ Напомню %s",
+		"unremind.usage":            "Укажите ID напоминания из /reminders",
+		"unremind.error":            "❌ Не удалось отменить напоминание.",
+		"unremind.not_found":        "Напоминание %s не найдено — проверьте ID в /reminders.",
+		"unremind.done":             "Напоминание отменено.",
+		"followup.usage":            "Укажите число дней после отклика (0 — не напоминать), пример:\n/followup 7",
+		"followup.error":            "Ошибка при сохранении настройки",
+		"followup.saved":            "Напоминание после отклика: через %s.",
+		"tz.usage":                  "Укажите часовой пояс в формате IANA, пример:\n/tz Europe/Moscow",
+		"tz.error":                  "Ошибка при сохранении часового пояса",
+		"tz.saved":                  "Часовой пояс сохранён: %s",
+		"thread.off":                "Сообщения будут приходить в основной чат.",
+		"thread.usage":              "Укажите ID темы форума (последнее число в ссылке на тему) или off, пример:\n/thread 123",
+		"thread.error":              "Ошибка при сохранении темы",
+		"thread.saved":              "Вакансии будут приходить в тему %s",
+		"settings.not_set":          "не установлены",
+		"settings.not_used":         "не учитывается",
+		"settings.default_interval": "по умолчанию (%s)",
+		"settings.text":             "📌 *Ваши настройки:*\n🔖 Теги: `%s`\n🏙️ Города: `%s`\n⏱️ Интервал: `%s`\n📰 Источники: `%s`\n🎯 Слова для оценки: `%s`\n🧰 Навыки: `%s`\n💰 Мин. зарплата: `%s`\n📊 Порог: `%s`\n💱 Валюта зарплат: `%s`\n🌐 Язык: `%s`",
+		"pause.error":               "❌ Не удалось поставить на паузу.",
+		"pause.done":                "⏸️ Поиск вакансий приостановлен. Для продолжения — /search.",
+		"search_add.example":        "Пример: /search_add Go в Европе | golang,go | Берлин,Амстердам",
+		"search_add.saved":          "✅ Поиск сохранён:\n%s\n\nИсточники можно выбрать командой /sources %s",
+		"search_del.usage":          "Укажите ID поиска из /searches. Основной поиск удалить нельзя — он задаётся командами /tags и /city",
+		"search_del.not_found":      "❌ Поиск %s не найден.",
+		"search_del.done":           "🗑 Поиск %s удалён.",
+		"sources.saved":             "📰 Источники поиска «%s»: %s\nДоступны: %s\nИзменить: /sources %s %s",
+		"channel_add.example":       "Пример: /channel_add email me@example.com",
+		"channel_add.added":         "✅ Канал %s добавлен: %s %s",
+		"channel_add.secret":        "🔑 Секрет подписи вебхука: %s\nЗаголовок X-Signature = sha256=HMAC-SHA256(секрет, X-Timestamp + \".\" + тело)",
+		"channel_add.route":         "Включить: /route telegram,%s",
+		"channel_del.usage":         "Укажите ID канала из /channels. Чат Telegram удалить нельзя — уберите его из /route",
+		"channel_del.not_found":     "❌ Канал %s не найден.",
+		"channel_del.done":          "🗑 Канал %s удалён.",
+		"route.example":             "Пример: /route telegram,a1b2c3",
+		"route.saved":               "📬 Каналы %s сохранены.",
+		"search.error":              "❌ Не удалось возобновить поиск.",
+		"search.already":            "🔄 Поиск уже активен.",
+		"search.resumed":            "✅ Поиск возобновлён.",
+
+		// Закладки и воронка
+		"saved.delete":    "🗑 Удалить",
+		"saved.status":    "Статус: %s",
+		"saved.error":     "❌ Не удалось получить сохранённые вакансии.",
+		"saved.empty":     "Сохранённых вакансий нет. Нажмите 📌 под карточкой вакансии или используйте /save <id>",
+		"saved.limited":   "Показаны последние %d из %d. Полная картина — /pipeline",
+		"pipeline.error":  "❌ Не удалось получить воронку.",
+		"pipeline.empty":  "Воронка пуста. Сохраните вакансию кнопкой 📌 или командой /save <id>",
+		"pipeline.title":  "📊 Воронка откликов:",
+		"pipeline.footer": "Сменить статус: /saved, заметка: /note <id> текст",
+
+		// Поиски
+		"search.main":             "Основной",
+		"search.load_failed":      "не удалось загрузить поиски",
+		"search.save_failed":      "не удалось сохранить поиск",
+		"search.too_many":         "можно сохранить не больше %d поисков",
+		"search.main_channels":    "каналы основного поиска задаются командой /route без ID поиска",
+		"search.name_and_tags":    "укажите название и теги",
+		"search.unknown_sources":  "неизвестные источники: %s. Доступны: %s",
+		"search.not_found":        "поиск %s не найден, список — /searches",
+		"searches.title":          "🔎 Ваши поиски:",
+		"searches.footer":         "Добавить: /search_add Название | теги | города\nУдалить: /search_del <id>\nИсточники: /sources <id> %s",
+		"searches.default_cities": "по умолчанию",
+		"searches.item":           "%s (%s)\n🔖 Теги: %s\n🏙️ Города: %s\n📰 Источники: %s",
+
+		// Каналы
+		"card.title":                   "❤ *Новая вакансия:* [%s](%s)",
+		"channel.not_found":            "канал %s не найден, список — /channels",
+		"channel_add.kind_and_address": "укажите вид канала и адрес",
+		"channel_add.telegram":         "этот чат уже подключён как канал telegram",
+		"channel_add.unavailable":      "канал %q недоступен. Доступны: %s",
+		"channel_add.too_many":         "можно добавить не больше %d каналов",
+		"channel_add.save_failed":      "не удалось сохранить канал",
+		"channels.load_failed":         "не удалось загрузить каналы",
+		"route.no_channels":            "укажите каналы через запятую",
+		"route.save_failed":            "не удалось сохранить каналы",
+		"route.default":                "по умолчанию",
+		"route.search":                 "поиска «%s»",
+		"channels.error":               "❌ Не удалось получить список каналов.",
+		"channels.title":               "📬 Каналы доставки:",
+		"channels.this_chat":           "telegram — этот чат",
+		"channels.default":             "По умолчанию: %s",
+		"channels.search":              "Поиск «%s»: %s",
+		"channels.footer":              "Добавить: /channel_add <вид> <адрес>, виды: %s\nУдалить: /channel_del <id>\nКуда слать: /route telegram,<id> или /route <id поиска> <id каналов>",
+
+		// Кнопки
+		"button.track":            "⭐ Следить",
+		"button.save":             "📌 Сохранить",
+		"callback.admins_only":    "🔒 Только для администраторов чата",
+		"callback.track_error":    "❌ Не удалось загрузить вакансию",
+		"callback.tracking":       "⭐ Слежу за «%s»",
+		"callback.save_error":     "❌ Не удалось сохранить вакансию",
+		"callback.not_saved":      "❌ Вакансия не найдена в сохранённых",
+		"callback.unsave_error":   "❌ Не удалось удалить",
+		"callback.unsaved":        "🗑 Удалено из сохранённых",
+		"callback.employer_error": "❌ Не удалось загрузить работодателя",
+
+		// Проверка вакансий
+		"check.nothing_found": "🔍 Новые вакансии не были найдены.",
+
+		// Трекер и работодатели
+		"tracker.changes":           "🔔 Изменения в вакансии «%s»",
+		"watch_employer.save_error": "❌ Не удалось сохранить работодателя.",
+		"watch_employer.done":       "🏢 Слежу за вакансиями «%s» (ID %s). Список: /employers",
+
+		// Разовый поиск
+		"find.sort.d":      "🕓 По дате",
+		"find.sort.s":      "💰 По зарплате",
+		"find.sort.r":      "🎯 По релевантности",
+		"find.usage":       "Введите запрос после команды, пример:\n/find golang Москва",
+		"find.save_error":  "❌ Не удалось сохранить запрос.",
+		"find.error":       "❌ Не удалось выполнить поиск на hh.ru.",
+		"find.expired":     "Поиск устарел, повторите /find",
+		"find.unavailable": "❌ hh.ru недоступен",
+		"find.nothing":     "Ничего не найдено.",
+		"find.found":       "%s — найдено %s",
+
+		// Рынок
+		"market.example":       "Пример: /market golang Москва 30d",
+		"market.load_error":    "❌ Не удалось получить статистику рынка.",
+		"market.chart_caption": "Столбики — новые вакансии за день, линия — медианная зарплата на руки, тыс. ₽",
+
+		// История
+		"layout.short_datetime":  "02.01 15:04",
+		"history.employers":      "Работодатели",
+		"history.action.tracked": "⭐ Отслеживается",
+		"history.action.saved":   "📌 Сохранена",
+		"history.action.unsaved": "🗑 Удалена из сохранённых",
+		"history.action.note":    "📝 Заметка",
+		"history.load_error":     "❌ Не удалось получить историю.",
+		"history.empty":          "История пуста. Здесь появятся вакансии, которые бот отправил за последние %s.",
+		"history.title":          "🗂 Отправленные вакансии: %d",
+		"history.truncated":      "Показаны последние %d. Уточните запрос: /history <слово> или выгрузите всё: /export csv",
+		"export.empty":           "История пуста — выгружать нечего.",
+		"export.sheet":           "История",
+		"export.usage":           "Формат: /export %s",
+		"export.error":           "❌ Не удалось выгрузить историю.",
+		"export.column.sent":     "Отправлена",
+		"export.column.vacancy":  "Вакансия",
+		"export.column.employer": "Компания",
+		"export.column.city":     "Город",
+		"export.column.salary":   "Зарплата",
+		"export.column.score":    "Балл",
+		"export.column.source":   "Источник",
+		"export.column.search":   "Поиск",
+		"export.column.url":      "Ссылка",
+		"export.column.actions":  "Действия",
+
+		// Персональные данные и напоминания
+		"mydata.caption":          "🗄 Всё, что бот хранит об этом чате: настройки, поиски, история, закладки, заметки и напоминания. Удалить — /deleteme",
+		"mydata.user_caption":     "🗄 Данные пользователя %d. Удалить: /user %d delete",
+		"mydata.error":            "❌ Не удалось собрать данные, попробуйте позже.",
+		"deleteme.confirm":        "⚠️ Удалить все данные этого чата?\n\nБудут удалены настройки, поиски, каналы, история отправленных вакансий, закладки, заметки, напоминания, токены лент и API, а рассылка вакансий остановится. Отменить удаление нельзя — при необходимости сначала сохраните архив: /mydata",
+		"deleteme.button.all":     "🗑 Удалить всё",
+		"deleteme.button.cancel":  "❌ Отмена",
+		"deleteme.cancelled":      "Удаление отменено",
+		"deleteme.error":          "❌ Не удалось удалить данные",
+		"deleteme.deleted":        "🗑 Данные удалены",
+		"deleteme.done":           "🗑 Все данные этого чата удалены, рассылка остановлена. Чтобы начать заново — /start",
+		"deleteuser.confirm":      "⚠️ Удалить все данные пользователя %d и остановить для него рассылку? Отменить удаление нельзя.",
+		"deleteuser.button":       "🗑 Удалить",
+		"deleteuser.done":         "🗑 Данные пользователя %d удалены, рассылка остановлена.",
+		"followup.fired":          "⏰ %s\nhttps://hh.ru/vacancy/%s\n%s\n\nСменить статус: /saved",
+		"followup.text":           "Нет ответа %s — напомнить о себе работодателю?",
+		"remind.fired":            "⏰ Напоминание",
+		"remind.not_enough_args":  "недостаточно аргументов",
+		"remind.bad_vacancy":      "не удалось распознать вакансию %q",
+		"remind.bad_date":         "дата должна быть в формате ГГГГ-ММ-ДД ЧЧ:ММ",
+		"remind.past":             "это время уже прошло",
+		"reminders.load_error":    "❌ Не удалось получить напоминания.",
+		"reminders.empty":         "Запланированных напоминаний нет.",
+		"reminders.title":         "⏰ Напоминания:",
+		"reminders.item":          "%s — %s, вакансия %s",
+		"reminders.kind.remind":   "напоминание",
+		"reminders.kind.followup": "нет ответа",
+
+		// Ленты и API
+		"feed.title":                "Вакансии: %s",
+		"feed.description":          "Теги: %s",
+		"feed.employer":             "Компания: %s",
+		"feed.city":                 "Город: %s",
+		"feed.salary":               "Зарплата: %s",
+		"feed.score":                "Балл: %d",
+		"feed.source":               "Источник: %s",
+		"feeds.load_error":          "❌ Не удалось получить токен лент.",
+		"feeds.save_error":          "❌ Не удалось выдать токен лент.",
+		"feeds.reset":               "🔄 Токен заменён, старые адреса лент больше не работают.",
+		"feeds.title":               "📡 Ленты ваших поисков (RSS, Atom, JSON Feed):",
+		"feeds.no_public_url":       "Внешний адрес сервера не настроен (PUBLIC_URL) — добавьте пути к адресу бота сами.",
+		"feeds.footer":              "В ленте — последние вакансии, отправленные по поиску. Адрес содержит секретный токен: не публикуйте его. Отозвать — /feed_reset",
+		"apitoken.none":             "Действующего токена API нет.",
+		"apitoken.revoked":          "🔒 Токен API отозван.",
+		"apitoken.error":            "❌ Не удалось выдать токен API.",
+		"apitoken.base_placeholder": "<адрес бота>",
+		"apitoken.issued":           "🔑 Токен API (показывается один раз, прежний больше не действует):\n%s\n\nПример: curl -H \"Authorization: Bearer %s\" %s/api/v1/searches\nОтозвать: /apitoken revoke",
+
+		// Администрирование
+		"broadcast.usage":         "Введите текст рассылки после команды, пример:\n/broadcast Бот будет недоступен с 02:00 до 03:00",
+		"broadcast.prepare_error": "❌ Не удалось подготовить рассылку.",
+		"broadcast.preview":       "📣 Предпросмотр рассылки (%s):\n\n%s",
+		"broadcast.button.send":   "✅ Отправить",
+		"broadcast.not_found":     "Рассылка не найдена или уже отправлена",
+		"broadcast.cancelled":     "Рассылка отменена",
+		"broadcast.started":       "📣 Рассылка запущена",
+		"broadcast.users_error":   "❌ Не удалось получить список пользователей.",
+		"broadcast.done":          "📣 Рассылка завершена: доставлено %d, заблокировали бота %d, ошибок %d",
+		"user.usage":              "Укажите chatID пользователя, пример:\n/user 123456789\n/user 123456789 reset\n/user 123456789 export\n/user 123456789 delete",
+		"user.bad_id":             "❌ Некорректный chatID",
+		"user.unknown_action":     "Неизвестное действие, доступны: reset, export, delete",
+		"user.reset_done":         "♻ Пользователь %d сброшен, чекер перезапущен.",
+		"user.info":               "👤 Пользователь %d\n🔖 Теги: %s\n🏙️ Города: %s\n⏱️ Интервал: %s\n⏸️ На паузе: %t, отключён: %t, чекер запущен: %t\n🕓 Последняя проверка: %s\n⭐ Отслеживает вакансий: %d, 📌 сохранено: %d, 🏢 работодателей: %d\n\nСбросить состояние: /user %d reset\nВыгрузить данные: /user %d export, удалить: /user %d delete",
+		"maintenance.on_error":    "❌ Не удалось включить режим обслуживания.",
+		"maintenance.on":          "🛠 Режим обслуживания включён: все проверки вакансий приостановлены. Вернуть — /resumeall",
+		"maintenance.off_error":   "❌ Не удалось выключить режим обслуживания.",
+		"maintenance.off":         "✅ Режим обслуживания выключен, проверки возобновлены.",
+		"stats.maintenance_on":    "включён",
+		"stats.maintenance_off":   "выключен",
+		"stats.text":              "📈 Статистика бота\n👥 Пользователи: %d (на паузе: %d, отключены: %d)\n▶ Активных чекеров: %d, работодателей: %d\n🔍 Проверок за последний час: %d\n📨 Отправлено вакансий за сутки: %d\n🌐 Запросов к hh.ru за сутки: %d, ошибок: %d (%.1f%%)\n🛠 Режим обслуживания: %s",
+
+		// Веб-кабинет
+		"web.login":                         "Вход",
+		"web.login.text":                    "Войдите через Telegram, чтобы управлять поисками, историей вакансий и закладками.",
+		"web.login.domain_hint":             "Кнопка входа работает, только если домен сайта указан у бота через /setdomain в @BotFather.",
+		"web.login.disabled":                "пользователь отключён",
+		"web.login.session_error":           "не удалось открыть сессию",
+		"web.login.no_hash":                 "нет подписи Telegram",
+		"web.login.bad_hash":                "подпись Telegram не совпала",
+		"web.login.expired":                 "вход устарел, войдите заново",
+		"web.login.no_id":                   "нет ID пользователя Telegram",
+		"web.bad_form":                      "недействительная форма, обновите страницу",
+		"web.logout":                        "Выйти",
+		"web.save":                          "Сохранить",
+		"web.add":                           "Добавить",
+		"web.delete":                        "Удалить",
+		"web.searches":                      "Поиски",
+		"web.searches.name":                 "Название",
+		"web.searches.name_placeholder":     "Go в Европе",
+		"web.searches.main_hint":            "Основной поиск: теги и города те же, что в /tags и /city. Пустые теги — поиск по запросу по умолчанию.",
+		"web.searches.tags":                 "Теги через запятую",
+		"web.searches.cities":               "Города через запятую",
+		"web.searches.cities_default":       "по умолчанию",
+		"web.searches.cities_placeholder":   "Берлин, Амстердам",
+		"web.searches.sources_hint":         "Источники (ничего не выбрано — только hh.ru):",
+		"web.searches.channels_hint":        "Каналы доставки (ничего не выбрано — каналы по умолчанию из /route):",
+		"web.searches.sources":              "Источники:",
+		"web.searches.channels":             "Каналы доставки:",
+		"web.searches.delete_confirm":       "Удалить поиск «%s»?",
+		"web.searches.new":                  "Новый поиск",
+		"web.searches.this_chat":            "Telegram — этот чат",
+		"web.searches.created":              "Поиск «%s» сохранён",
+		"web.searches.updated":              "Поиск «%s» обновлён",
+		"web.searches.deleted":              "Поиск удалён",
+		"web.searches.not_found":            "поиск не найден",
+		"web.searches.main_delete":          "основной поиск удалить нельзя",
+		"web.history":                       "История",
+		"web.history.title":                 "История совпадений",
+		"web.history.all_searches":          "Все поиски",
+		"web.history.all_sources":           "Все источники",
+		"web.history.all_time":              "За всё время",
+		"web.history.day":                   "За сутки",
+		"web.history.week":                  "За неделю",
+		"web.history.month":                 "За месяц",
+		"web.history.query_placeholder":     "Название, компания или город",
+		"web.history.show":                  "Показать",
+		"web.history.vacancy":               "Вакансия",
+		"web.history.salary":                "Зарплата",
+		"web.history.search":                "Поиск",
+		"web.history.found":                 "Найдена",
+		"web.history.bookmark":              "В закладки",
+		"web.history.empty":                 "Ничего не найдено. В историю попадают вакансии, отправленные по вашим поискам.",
+		"web.bookmarks":                     "Закладки",
+		"web.bookmarks.vacancy_placeholder": "ID или ссылка на вакансию hh.ru",
+		"web.bookmarks.saved_at":            "сохранена %s",
+		"web.bookmarks.change":              "Сменить",
+		"web.bookmarks.note":                "Заметка",
+		"web.bookmarks.load_error":          "не удалось получить закладки",
+		"web.bookmarks.no_vacancy":          "укажите ID или ссылку на вакансию hh.ru",
+		"web.bookmarks.save_error":          "не удалось сохранить вакансию %s",
+		"web.bookmarks.saved":               "Сохранено: %s",
+		"web.bookmarks.bad_status":          "неизвестный статус",
+		"web.bookmarks.not_found":           "закладка не найдена",
+		"web.bookmarks.status_changed":      "Статус изменён: %s",
+		"web.bookmarks.empty_note":          "заметка пустая",
+		"web.bookmarks.note_added":          "Заметка добавлена",
+		"web.bookmarks.delete_error":        "не удалось удалить закладку",
+		"web.bookmarks.deleted":             "Закладка удалена",
+
+		// Подтверждение каналов
+		"channel.private_webhook":    "вебхук указывает на локальный или внутренний адрес",
+		"channel.confirm_subject":    "Код подтверждения hhruBot",
+		"channel.confirm_body":       "Код для подтверждения адреса: %s\n\nОтправьте боту /channel_confirm <id канала> <код> в течение часа. Если вы не добавляли этот адрес, просто проигнорируйте письмо — без подтверждения вакансии сюда не придут.",
+		"channel_add.code_throttled": "код подтверждения уже отправлялся меньше минуты назад, попробуйте позже",
+		"channel_add.code_failed":    "не удалось отправить код подтверждения, проверьте адрес",
+		"channel_add.confirm":        "📧 На %s отправлен код. Подтвердите адрес: /channel_confirm %s <код> — до этого вакансии туда не пойдут.",
+		"channel_confirm.usage":      "укажите ID канала и код: /channel_confirm <id> <код>",
+		"channel_confirm.failed":     "не удалось подтвердить канал",
+		"channel_confirm.bad_code":   "неверный или истёкший код",
+		"channel_confirm.attempts":   "слишком много неверных попыток, код сброшен — удалите канал (/channel_del %s) и добавьте его заново, чтобы получить новый код",
+		"channel_confirm.done":       "✅ Канал %s подтверждён.",
+		"channels.pending":           "(не подтверждён: /channel_confirm %s <код>)",
+
+		// Ошибки HTTP API
+		"api.no_token":       "нужен заголовок Authorization: Bearer <токен из /apitoken>",
+		"api.bad_token":      "токен недействителен",
+		"api.disabled":       "пользователь отключён",
+		"api.main_rename":    "основной поиск нельзя переименовать",
+		"api.main_delete":    "основной поиск удалить нельзя",
+		"api.not_found":      "поиск не найден",
+		"api.history_error":  "не удалось загрузить историю",
+		"api.pause_error":    "не удалось поставить на паузу",
+		"api.resume_error":   "не удалось возобновить поиск",
+		"api.pipeline_error": "не удалось получить воронку",
+		"api.bad_json":       "некорректный JSON: %s",
+		"api.internal":       "внутренняя ошибка",
+
+		// Секреты в группах
+		"private.for_chat":    "Для чата «%s»:",
+		"private.sent":        "🔒 Отправил в личные сообщения тому, кто выполнил команду.",
+		"private.failed":      "🔒 Не удалось написать вам в личку: откройте чат с ботом, нажмите «Старт» и повторите команду.",
+		"private.unavailable": "🔒 Команда присылает секретные данные в личные сообщения, а автора сообщения в канале бот не видит. Выполните её в личке с ботом или в группе.",
+
+		// ID вакансий
+		"vacancy_id.foreign": "%s — вакансия не с hh.ru. Отслеживать, сохранять, добавлять заметки и напоминания можно только для вакансий hh.ru: укажите число или ссылку https://hh.ru/vacancy/…",
+	},
+}
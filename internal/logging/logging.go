@@ -0,0 +1,80 @@
+// Структурированное логирование на log/slog
+package logging
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// Ключи атрибутов, которые используются во всех пакетах бота
+const (
+	KeyChatID    = "chat_id"
+	KeySearchID  = "search_id"
+	KeyVacancyID = "vacancy_id"
+	KeyHHStatus  = "hh_status"
+	KeyAttempt   = "attempt"
+	KeyError     = "error"
+)
+
+// Атрибуты с текстом, который ввёл пользователь: в логах заменяются на длину
+var redactedKeys = map[string]bool{
+	"text":   true,
+	"query":  true,
+	"tags":   true,
+	"cities": true,
+	"note":   true,
+}
+
+// Setup настраивает логгер по умолчанию: формат text или json, уровень debug/info/warn/error
+func Setup(level, format string) error {
+	return SetupWriter(os.Stderr, level, format)
+}
+
+func SetupWriter(w io.Writer, level, format string) error {
+	var lvl slog.Level
+	if err := lvl.UnmarshalText([]byte(strings.ToUpper(strings.TrimSpace(level)))); err != nil {
+		return fmt.Errorf("неизвестный уровень логирования %q", level)
+	}
+
+	opts := &slog.HandlerOptions{Level: lvl, ReplaceAttr: redact}
+
+	var handler slog.Handler
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case "", "text":
+		handler = slog.NewTextHandler(w, opts)
+	case "json":
+		handler = slog.NewJSONHandler(w, opts)
+	default:
+		return fmt.Errorf("неизвестный формат логов %q (text или json)", format)
+	}
+
+	slog.SetDefault(slog.New(handler))
+	return nil
+}
+
+// Err — атрибут с ошибкой. Адрес запроса из *url.Error вырезается: в нём бывают
+// поисковые запросы пользователя, секреты вебхуков и токен бота.
+func Err(err error) slog.Attr {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) && urlErr.URL != "" {
+		return slog.String(KeyError, strings.ReplaceAll(err.Error(), urlErr.URL, "[адрес скрыт]"))
+	}
+	return slog.Any(KeyError, err)
+}
+
+// Chat — логгер с идентификатором чата
+func Chat(chatID int64) *slog.Logger {
+	return slog.With(KeyChatID, chatID)
+}
+
+func redact(_ []string, a slog.Attr) slog.Attr {
+	if redactedKeys[a.Key] && a.Value.Kind() == slog.KindString {
+		return slog.String(a.Key, fmt.Sprintf("[скрыто, %d симв.]", len([]rune(a.Value.String()))))
+	}
+	return a
+}
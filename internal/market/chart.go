@@ -0,0 +1,173 @@
+package market
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+	"strconv"
+)
+
+const (
+	chartWidth  = 800
+	chartHeight = 400
+	chartMargin = 40
+)
+
+var (
+	colorBackground = color.RGBA{255, 255, 255, 255}
+	colorAxis       = color.RGBA{120, 120, 120, 255}
+	colorGrid       = color.RGBA{230, 230, 230, 255}
+	colorBars       = color.RGBA{66, 133, 244, 255}
+	colorMedian     = color.RGBA{244, 140, 36, 255}
+)
+
+// Chart рисует PNG: столбики — новые вакансии за день (шкала слева),
+// линия — медианная зарплата в тысячах рублей (шкала справа)
+func Chart(r Report) ([]byte, error) {
+	img := image.NewRGBA(image.Rect(0, 0, chartWidth, chartHeight))
+	draw.Draw(img, img.Bounds(), &image.Uniform{colorBackground}, image.Point{}, draw.Src)
+
+	left, right := chartMargin, chartWidth-chartMargin
+	top, bottom := chartMargin/2, chartHeight-chartMargin
+	plotH := bottom - top
+
+	maxCount, maxMedian := 1, 0.0
+	for _, p := range r.Daily {
+		maxCount = max(maxCount, p.Count)
+		maxMedian = math.Max(maxMedian, p.Median)
+	}
+
+	for i := 1; i <= 4; i++ {
+		y := bottom - plotH*i/4
+		hline(img, left, right, y, colorGrid)
+	}
+
+	n := max(len(r.Daily), 1)
+	slot := float64(right-left) / float64(n)
+	barW := max(int(slot*0.7), 1)
+	var prevX, prevY int
+	hasPrev := false
+	for i, p := range r.Daily {
+		x := left + int(slot*float64(i)+slot*0.15)
+		h := plotH * p.Count / maxCount
+		fillRect(img, x, bottom-h, x+barW, bottom, colorBars)
+
+		if p.Median <= 0 || maxMedian <= 0 {
+			hasPrev = false
+			continue
+		}
+		cx := x + barW/2
+		cy := bottom - int(float64(plotH)*p.Median/maxMedian)
+		fillRect(img, cx-2, cy-2, cx+3, cy+3, colorMedian)
+		if hasPrev {
+			line(img, prevX, prevY, cx, cy, colorMedian)
+		}
+		prevX, prevY, hasPrev = cx, cy, true
+	}
+
+	hline(img, left, right, bottom, colorAxis)
+	vline(img, left, top, bottom, colorAxis)
+	vline(img, right, top, bottom, colorAxis)
+
+	// Подписи: максимум шкал и число дней. Шрифтов в стандартной библиотеке нет,
+	// поэтому цифры рисуем своим растровым шрифтом.
+	drawText(img, 4, top, strconv.Itoa(maxCount), colorBars)
+	if maxMedian > 0 {
+		drawText(img, right+4, top, strconv.Itoa(int(math.Round(maxMedian/1000)))+"k", colorMedian)
+	}
+	drawText(img, 4, bottom-10, "0", colorAxis)
+	drawText(img, right-30, bottom+8, strconv.Itoa(len(r.Daily))+"d", colorAxis)
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func fillRect(img *image.RGBA, x0, y0, x1, y1 int, c color.Color) {
+	draw.Draw(img, image.Rect(x0, y0, x1, y1), &image.Uniform{c}, image.Point{}, draw.Src)
+}
+
+func hline(img *image.RGBA, x0, x1, y int, c color.Color) {
+	fillRect(img, x0, y, x1+1, y+1, c)
+}
+
+func vline(img *image.RGBA, x, y0, y1 int, c color.Color) {
+	fillRect(img, x, y0, x+1, y1+1, c)
+}
+
+// line — отрезок толщиной 2 пикселя по алгоритму Брезенхэма
+func line(img *image.RGBA, x0, y0, x1, y1 int, c color.Color) {
+	dx, dy := abs(x1-x0), -abs(y1-y0)
+	sx, sy := sign(x1-x0), sign(y1-y0)
+	e := dx + dy
+	for {
+		fillRect(img, x0, y0, x0+2, y0+2, c)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		if e2 := 2 * e; e2 >= dy {
+			e += dy
+			x0 += sx
+		} else {
+			e += dx
+			y0 += sy
+		}
+	}
+}
+
+// glyphs — растровый шрифт 3×5 для цифр и подписей шкал
+var glyphs = map[rune][5]string{
+	'0': {"###", "#.#", "#.#", "#.#", "###"},
+	'1': {".#.", "##.", ".#.", ".#.", "###"},
+	'2': {"###", "..#", "###", "#..", "###"},
+	'3': {"###", "..#", "###", "..#", "###"},
+	'4': {"#.#", "#.#", "###", "..#", "..#"},
+	'5': {"###", "#..", "###", "..#", "###"},
+	'6': {"###", "#..", "###", "#.#", "###"},
+	'7': {"###", "..#", "..#", "..#", "..#"},
+	'8': {"###", "#.#", "###", "#.#", "###"},
+	'9': {"###", "#.#", "###", "..#", "###"},
+	'k': {"#..", "#.#", "##.", "#.#", "#.#"},
+	'd': {"..#", "..#", "###", "#.#", "###"},
+}
+
+// drawText пишет текст шрифтом glyphs с увеличением 2×
+func drawText(img *image.RGBA, x, y int, text string, c color.Color) {
+	const scale = 2
+	for _, ch := range text {
+		g, ok := glyphs[ch]
+		if ok {
+			for row, bits := range g {
+				for col, bit := range bits {
+					if bit == '#' {
+						px, py := x+col*scale, y+row*scale
+						fillRect(img, px, py, px+scale, py+scale, c)
+					}
+				}
+			}
+		}
+		x += 4 * scale
+	}
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+func sign(v int) int {
+	switch {
+	case v > 0:
+		return 1
+	case v < 0:
+		return -1
+	}
+	return 0
+}
@@ -0,0 +1,190 @@
+// Аналитика рынка: спрос и зарплаты по запросу за период
+package market
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"hhruBot/internal/i18n"
+	"hhruBot/internal/storage"
+)
+
+const (
+	DefaultDays = 30
+	MaxDays     = 90
+
+	// AllCities — ключ статистики по всем городам вместе
+	AllCities = "*"
+
+	// Сколько работодателей и навыков показываем в отчёте
+	topLimit = 10
+)
+
+// Query — запрос отчёта: тег, город (пусто — все города) и период в днях
+type Query struct {
+	Tag  string
+	City string
+	Days int
+}
+
+// ParseQuery разбирает "golang Москва 30d": первый аргумент — тег, последний
+// вида "30d" — период, всё между ними — город
+func ParseQuery(args string) (Query, error) {
+	fields := strings.Fields(args)
+	if len(fields) == 0 {
+		return Query{}, i18n.Errorf("market.no_tag")
+	}
+
+	q := Query{Tag: fields[0], Days: DefaultDays}
+	fields = fields[1:]
+	if n := len(fields); n > 0 {
+		if days, ok := strings.CutSuffix(strings.ToLower(fields[n-1]), "d"); ok {
+			d, err := strconv.Atoi(days)
+			if err != nil || d < 1 || d > MaxDays {
+				return Query{}, i18n.Errorf("market.bad_period", MaxDays)
+			}
+			q.Days = d
+			fields = fields[:n-1]
+		}
+	}
+	q.City = strings.Join(fields, " ")
+	return q, nil
+}
+
+// Key — нормализованные тег и город, под которыми лежит статистика
+func Key(tag, city string) (string, string) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	city = strings.ToLower(strings.TrimSpace(city))
+	if city == "" {
+		city = AllCities
+	}
+	return tag, city
+}
+
+// Count — сколько раз встретился работодатель или навык
+type Count struct {
+	Name  string
+	Count int
+}
+
+// DailyPoint — точка графика: новые вакансии и медианная зарплата за день
+type DailyPoint struct {
+	Date   time.Time
+	Count  int
+	Median float64 // 0 — зарплат за день не было
+}
+
+// Report — сводка по запросу за период
+type Report struct {
+	Total        int
+	WithSalary   int
+	P25, P50     float64
+	P75, P90     float64
+	TopEmployers []Count
+	TopSkills    []Count
+	Daily        []DailyPoint
+}
+
+// Build собирает отчёт из дневной статистики
+func Build(days []storage.MarketDay) Report {
+	var r Report
+	var salaries []float64
+	employers := make(map[string]int)
+	skills := make(map[string]int)
+	for _, d := range days {
+		r.Total += d.Count
+		salaries = append(salaries, d.Salaries...)
+		for name, n := range d.Employers {
+			employers[name] += n
+		}
+		for name, n := range d.Skills {
+			skills[name] += n
+		}
+		r.Daily = append(r.Daily, DailyPoint{Date: d.Date, Count: d.Count, Median: percentile(sorted(d.Salaries), 50)})
+	}
+
+	r.WithSalary = len(salaries)
+	s := sorted(salaries)
+	r.P25, r.P50, r.P75, r.P90 = percentile(s, 25), percentile(s, 50), percentile(s, 75), percentile(s, 90)
+	r.TopEmployers = top(employers, topLimit)
+	r.TopSkills = top(skills, topLimit)
+	return r
+}
+
+// Text — отчёт для Telegram на языке lang
+func (r Report) Text(q Query, lang i18n.Lang) string {
+	var sb strings.Builder
+	where := q.City
+	if where == "" {
+		where = lang.T("market.all_cities")
+	}
+	sb.WriteString(lang.T("market.title", q.Tag, where, lang.N("days", q.Days)) + "\n\n")
+	if r.Total == 0 {
+		sb.WriteString(lang.T("market.no_data"))
+		return sb.String()
+	}
+
+	sb.WriteString(lang.T("market.total", r.Total, float64(r.Total)/float64(max(len(r.Daily), 1))) + "\n")
+	if r.WithSalary > 0 {
+		sb.WriteString(lang.T("market.salaries", lang.N("market.with_salary", r.WithSalary)) + "\n")
+		sb.WriteString(lang.T("market.percentiles",
+			FormatRub(r.P25, lang), FormatRub(r.P50, lang), FormatRub(r.P75, lang), FormatRub(r.P90, lang)) + "\n")
+	} else {
+		sb.WriteString(lang.T("market.no_salaries") + "\n")
+	}
+
+	if len(r.TopEmployers) > 0 {
+		sb.WriteString("\n" + lang.T("market.top_employers") + "\n")
+		for _, c := range r.TopEmployers {
+			fmt.Fprintf(&sb, "   %s — %d\n", c.Name, c.Count)
+		}
+	}
+	if len(r.TopSkills) > 0 {
+		sb.WriteString("\n" + lang.T("market.top_skills") + "\n")
+		for _, c := range r.TopSkills {
+			fmt.Fprintf(&sb, "   %s — %d\n", c.Name, c.Count)
+		}
+	}
+	return sb.String()
+}
+
+// FormatRub — «185 тыс. ₽»
+func FormatRub(v float64, lang i18n.Lang) string {
+	return lang.T("market.thousand_rub", math.Round(v/1000))
+}
+
+func sorted(values []float64) []float64 {
+	s := append([]float64(nil), values...)
+	sort.Float64s(s)
+	return s
+}
+
+// percentile — по методу ближайшего ранга; values должны быть отсортированы
+func percentile(values []float64, p float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(values))))
+	return values[max(rank-1, 0)]
+}
+
+func top(counts map[string]int, limit int) []Count {
+	result := make([]Count, 0, len(counts))
+	for name, n := range counts {
+		result = append(result, Count{Name: name, Count: n})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Name < result[j].Name
+	})
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result
+}
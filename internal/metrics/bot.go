@@ -0,0 +1,27 @@
+package metrics
+
+// Метрики бота
+var (
+	HHRequests = NewCounterVec("hhbot_hh_requests_total",
+		"Запросы к API hh.ru по HTTP-статусу (0 — сетевая ошибка)", "status")
+	HHRequestDuration = NewHistogramVec("hhbot_hh_request_duration_seconds",
+		"Длительность запросов к API hh.ru", DefaultBuckets, "status")
+
+	VacanciesFound = NewCounterVec("hhbot_vacancies_found_total",
+		"Вакансии, полученные от hh.ru при проверках")
+	VacanciesSent = NewCounterVec("hhbot_vacancies_sent_total",
+		"Вакансии, отправленные пользователям")
+
+	TelegramSendErrors = NewCounterVec("hhbot_telegram_send_errors_total",
+		"Ошибки отправки сообщений в Telegram по коду ошибки", "code")
+	NotifyErrors = NewCounterVec("hhbot_notify_errors_total",
+		"Ошибки доставки вакансий по каналу (telegram, email, slack, ...)", "channel")
+
+	ActiveCheckers = NewGauge("hhbot_active_checkers",
+		"Запущенные чекеры вакансий и работодателей")
+	QueueDepth = NewGaugeFunc("hhbot_timer_queue_depth",
+		"Запланированные таймеры (напоминания) в очереди")
+
+	RedisLatency = NewHistogramVec("hhbot_redis_command_duration_seconds",
+		"Длительность команд Redis", DefaultBuckets, "command")
+)
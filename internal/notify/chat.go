@@ -0,0 +1,77 @@
+package notify
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"hhruBot/internal/i18n"
+)
+
+// slack — входящий вебхук Slack (Incoming Webhooks), одно сообщение на вакансию
+type slack struct {
+	client *http.Client
+}
+
+func (s slack) Send(target Target, items []Item) error {
+	for _, item := range items {
+		text := fmt.Sprintf("*<%s|%s>*", item.URL, slackEscape(item.Vacancy.Name))
+		if rest := detailLines(item, target.Lang); rest != "" {
+			text += "\n" + slackEscape(rest)
+		}
+		if err := postJSON(s.client, http.MethodPost, target.Address, map[string]string{"text": text}, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func slackEscape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
+
+// discord — вебхук канала Discord; до 10 вакансий в одном сообщении
+type discord struct {
+	client *http.Client
+}
+
+type discordEmbed struct {
+	Title       string `json:"title"`
+	URL         string `json:"url"`
+	Description string `json:"description,omitempty"`
+}
+
+const discordEmbedsPerMessage = 10
+
+func (d discord) Send(target Target, items []Item) error {
+	for start := 0; start < len(items); start += discordEmbedsPerMessage {
+		end := min(start+discordEmbedsPerMessage, len(items))
+		var embeds []discordEmbed
+		for _, item := range items[start:end] {
+			embeds = append(embeds, discordEmbed{
+				Title:       truncate(item.Vacancy.Name, 256),
+				URL:         item.URL,
+				Description: truncate(detailLines(item, target.Lang), 4096),
+			})
+		}
+		if err := postJSON(d.client, http.MethodPost, target.Address, map[string]any{"embeds": embeds}, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// detailLines — всё, кроме названия и ссылки: компания, город, зарплата, балл
+func detailLines(item Item, lang i18n.Lang) string {
+	text := PlainText(item, lang)
+	_, rest, _ := strings.Cut(text, "\n")
+	return strings.TrimSpace(strings.TrimSuffix(rest, item.URL))
+}
+
+func truncate(s string, limit int) string {
+	runes := []rune(s)
+	if len(runes) <= limit {
+		return s
+	}
+	return string(runes[:limit-1]) + "…"
+}
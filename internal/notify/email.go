@@ -0,0 +1,54 @@
+package notify
+
+import (
+	"fmt"
+	"mime"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// smtpMailer отправляет дайджест вакансий одним письмом
+type smtpMailer struct {
+	addr     string
+	host     string
+	username string
+	password string
+	from     string
+}
+
+func (m smtpMailer) Send(target Target, items []Item) error {
+	var body strings.Builder
+	for i, item := range items {
+		if i > 0 {
+			body.WriteString("\n\n———\n\n")
+		}
+		body.WriteString(PlainText(item, target.Lang))
+	}
+	return m.send(target.Address, Subject(items, target.Lang), body.String())
+}
+
+// SendCode присылает код подтверждения адреса
+func (m smtpMailer) SendCode(target Target, code string) error {
+	l := target.Lang
+	return m.send(target.Address, l.T("channel.confirm_subject"), l.T("channel.confirm_body", code))
+}
+
+func (m smtpMailer) send(to, subject, body string) error {
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "From: %s\r\n", m.from)
+	fmt.Fprintf(&msg, "To: %s\r\n", to)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	msg.WriteString("Content-Transfer-Encoding: 8bit\r\n\r\n")
+	msg.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+	msg.WriteString("\r\n")
+
+	var auth smtp.Auth
+	if m.username != "" {
+		auth = smtp.PlainAuth("", m.username, m.password, m.host)
+	}
+	return smtp.SendMail(m.addr, auth, m.from, []string{to}, []byte(msg.String()))
+}
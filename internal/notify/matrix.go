@@ -0,0 +1,50 @@
+package notify
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// matrix — сообщения в комнату Matrix от имени бота (токен из конфигурации).
+// Бот должен быть приглашён в комнату.
+type matrix struct {
+	client     *http.Client
+	homeserver string
+	token      string
+}
+
+func (m matrix) Send(target Target, items []Item) error {
+	for _, item := range items {
+		lines := strings.Split(html.EscapeString(detailLines(item, target.Lang)), "\n")
+		formatted := fmt.Sprintf(`<a href="%s"><b>%s</b></a>`, html.EscapeString(item.URL), html.EscapeString(item.Vacancy.Name))
+		if len(lines) > 0 && lines[0] != "" {
+			formatted += "<br>" + strings.Join(lines, "<br>")
+		}
+
+		payload := map[string]string{
+			"msgtype":        "m.text",
+			"body":           PlainText(item, target.Lang),
+			"format":         "org.matrix.custom.html",
+			"formatted_body": formatted,
+		}
+		endpoint := fmt.Sprintf("%s/_matrix/client/v3/rooms/%s/send/m.room.message/%s",
+			m.homeserver, url.PathEscape(target.Address), txnID())
+		headers := map[string]string{"Authorization": "Bearer " + m.token}
+		if err := postJSON(m.client, http.MethodPut, endpoint, payload, headers); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// txnID — идентификатор транзакции Matrix, защищает от повторной отправки
+func txnID() string {
+	buf := make([]byte, 8)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
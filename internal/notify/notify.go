@@ -0,0 +1,188 @@
+// Каналы доставки вакансий: Telegram, почта, Slack, Discord, Matrix и вебхуки
+package notify
+
+import (
+	"fmt"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"strings"
+
+	"hhruBot/internal/config"
+	"hhruBot/internal/hh"
+	"hhruBot/internal/i18n"
+)
+
+// Виды каналов
+const (
+	Telegram = "telegram"
+	Email    = "email"
+	Slack    = "slack"
+	Discord  = "discord"
+	Matrix   = "matrix"
+	Webhook  = "webhook"
+)
+
+// Kinds — виды каналов в порядке показа пользователю
+var Kinds = []string{Telegram, Email, Slack, Discord, Matrix, Webhook}
+
+// Title — название канала для пользователя
+func Title(kind string, lang i18n.Lang) string {
+	return lang.T("channel." + kind)
+}
+
+// Item — вакансия, подготовленная к отправке
+type Item struct {
+	Vacancy    hh.Vacancy
+	URL        string
+	Salary     string   // отформатированная зарплата; пусто — не указана
+	OtherAreas []string // города дубликатов из той же группы
+	Score      int
+	Reasons    []string
+	WithScore  bool
+	Search     string // название поиска, если у пользователя их несколько
+	Source     string // название источника, если это не hh.ru
+}
+
+// Target — куда доставлять: адрес почты, URL вебхука, комната Matrix
+type Target struct {
+	Kind    string
+	Address string
+	Secret  string    // ключ подписи для вебхука
+	Lang    i18n.Lang // язык текста вакансий
+}
+
+// Notifier — канал доставки
+type Notifier interface {
+	Send(target Target, items []Item) error
+}
+
+// IsDigest сообщает, что канал получает все вакансии проверки одним вызовом
+// (письмо-дайджест). Остальным каналам вакансии передаются по одной, чтобы
+// сбой одной отправки не мешал пометить доставленными остальные.
+func IsDigest(kind string) bool {
+	return kind == Email
+}
+
+// Set — доступные каналы по виду
+type Set map[string]Notifier
+
+// FromConfig собирает каналы, которые не требуют бота. Почта и Matrix
+// доступны, только если в конфигурации указан сервер. Telegram добавляет бот.
+func FromConfig(cfg *config.Config) Set {
+	hooks := publicClient(cfg.HH.Timeout)
+	set := Set{
+		Slack:   slack{client: hooks},
+		Discord: discord{client: hooks},
+		Webhook: webhook{client: hooks},
+	}
+	if cfg.Notify.SMTPHost != "" {
+		set[Email] = smtpMailer{
+			addr:     fmt.Sprintf("%s:%d", cfg.Notify.SMTPHost, cfg.Notify.SMTPPort),
+			host:     cfg.Notify.SMTPHost,
+			username: cfg.Notify.SMTPUsername,
+			password: cfg.Notify.SMTPPassword,
+			from:     cfg.Notify.SMTPFrom,
+		}
+	}
+	if cfg.Notify.MatrixHomeserver != "" {
+		set[Matrix] = matrix{
+			client:     &http.Client{Timeout: cfg.HH.Timeout},
+			homeserver: strings.TrimRight(cfg.Notify.MatrixHomeserver, "/"),
+			token:      cfg.Notify.MatrixToken,
+		}
+	}
+	return set
+}
+
+// Names возвращает доступные виды каналов в порядке Kinds
+func (s Set) Names() []string {
+	var names []string
+	for _, kind := range Kinds {
+		if _, ok := s[kind]; ok {
+			names = append(names, kind)
+		}
+	}
+	return names
+}
+
+// ValidateTarget проверяет адрес канала перед сохранением и возвращает его в
+// том виде, в каком он будет использоваться: у почты — только сам адрес без
+// имени. Вебхуки принимаются только по https и только на публичные адреса.
+func ValidateTarget(kind, address string) (string, error) {
+	switch kind {
+	case Email:
+		addr, err := mail.ParseAddress(address)
+		if err != nil {
+			return "", i18n.Errorf("channel.bad_email")
+		}
+		return addr.Address, nil
+	case Slack, Discord, Webhook:
+		u, err := url.Parse(address)
+		if err != nil || u.Scheme != "https" || u.Hostname() == "" || u.User != nil {
+			return "", i18n.Errorf("channel.bad_webhook")
+		}
+		if err := checkPublicHost(u.Hostname()); err != nil {
+			return "", i18n.Errorf("channel.private_webhook")
+		}
+	case Matrix:
+		if !strings.HasPrefix(address, "!") || !strings.Contains(address, ":") {
+			return "", i18n.Errorf("channel.bad_matrix")
+		}
+	default:
+		return "", i18n.Errorf("channel.unknown", kind)
+	}
+	return address, nil
+}
+
+// Confirmer — канал, адрес которого пользователь должен подтвердить кодом,
+// прежде чем туда пойдут вакансии: иначе бот можно направить на чужую почту
+type Confirmer interface {
+	SendCode(target Target, code string) error
+}
+
+// PlainText — текст вакансии без разметки для почты и Matrix
+func PlainText(item Item, lang i18n.Lang) string {
+	var sb strings.Builder
+	v := item.Vacancy
+	fmt.Fprintf(&sb, "%s\n", v.Name)
+	if v.Employer.Name != "" {
+		sb.WriteString(lang.T("card.employer", v.Employer.Name) + "\n")
+	}
+	if v.Area.Name != "" {
+		sb.WriteString(lang.T("card.area", v.Area.Name) + "\n")
+	}
+	if len(item.OtherAreas) > 0 {
+		sb.WriteString(lang.T("card.other_areas", strings.Join(item.OtherAreas, ", ")) + "\n")
+	}
+	if item.Salary != "" {
+		sb.WriteString(lang.T("card.salary", item.Salary) + "\n")
+	}
+	if item.WithScore {
+		sb.WriteString(lang.T("card.score", item.Score))
+		if len(item.Reasons) > 0 {
+			fmt.Fprintf(&sb, " (%s)", strings.Join(item.Reasons, ", "))
+		}
+		sb.WriteString("\n")
+	}
+	if item.Source != "" {
+		sb.WriteString(lang.T("card.source", item.Source) + "\n")
+	}
+	if item.Search != "" {
+		sb.WriteString(lang.T("card.search", item.Search) + "\n")
+	}
+	sb.WriteString(item.URL)
+	return sb.String()
+}
+
+// Subject — заголовок дайджеста
+func Subject(items []Item, lang i18n.Lang) string {
+	if len(items) == 1 {
+		return lang.T("card.subject_one", items[0].Vacancy.Name)
+	}
+	subject := lang.T("card.subject_many", len(items))
+	if items[0].Search != "" {
+		subject += " — " + items[0].Search
+	}
+	return subject
+}
@@ -0,0 +1,83 @@
+package notify
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// webhook — JSON-вебхук с подписью HMAC-SHA256. Получатель проверяет
+// X-Signature = hex(HMAC(секрет, X-Timestamp + "." + тело запроса)).
+type webhook struct {
+	client *http.Client
+}
+
+type webhookVacancy struct {
+	Id         string   `json:"id"`
+	Name       string   `json:"name"`
+	URL        string   `json:"url"`
+	Employer   string   `json:"employer,omitempty"`
+	Area       string   `json:"area,omitempty"`
+	OtherAreas []string `json:"other_areas,omitempty"`
+	Salary     string   `json:"salary,omitempty"`
+	Score      *int     `json:"score,omitempty"`
+	Reasons    []string `json:"reasons,omitempty"`
+	Source     string   `json:"source"`
+	Search     string   `json:"search,omitempty"`
+}
+
+type webhookPayload struct {
+	Event     string           `json:"event"`
+	Vacancies []webhookVacancy `json:"vacancies"`
+}
+
+func (w webhook) Send(target Target, items []Item) error {
+	payload := webhookPayload{Event: "vacancies.matched"}
+	for _, item := range items {
+		v := item.Vacancy
+		source := v.Source
+		if source == "" {
+			source = "hh"
+		}
+		wv := webhookVacancy{
+			Id:         v.Id,
+			Name:       v.Name,
+			URL:        item.URL,
+			Employer:   v.Employer.Name,
+			Area:       v.Area.Name,
+			OtherAreas: item.OtherAreas,
+			Salary:     item.Salary,
+			Reasons:    item.Reasons,
+			Source:     source,
+			Search:     item.Search,
+		}
+		if item.WithScore {
+			score := item.Score
+			wv.Score = &score
+		}
+		payload.Vacancies = append(payload.Vacancies, wv)
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	headers := map[string]string{
+		"X-Timestamp": timestamp,
+		"X-Signature": Sign(target.Secret, timestamp, body),
+	}
+	return postJSON(w.client, http.MethodPost, target.Address, json.RawMessage(body), headers)
+}
+
+// Sign вычисляет подпись вебхука
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,35 @@
+// Воронка откликов: статусы сохранённых вакансий
+package pipeline
+
+import "hhruBot/internal/i18n"
+
+type Status string
+
+const (
+	Saved     Status = "saved"
+	Applied   Status = "applied"
+	Interview Status = "interview"
+	Offer     Status = "offer"
+	Rejected  Status = "rejected"
+)
+
+// Statuses — все статусы в порядке движения по воронке
+var Statuses = []Status{Saved, Applied, Interview, Offer, Rejected}
+
+// Parse проверяет, что строка — известный статус
+func Parse(s string) (Status, bool) {
+	for _, st := range Statuses {
+		if string(st) == s {
+			return st, true
+		}
+	}
+	return "", false
+}
+
+// Title — подпись статуса для пользователя
+func (s Status) Title(lang i18n.Lang) string {
+	if _, ok := Parse(string(s)); !ok {
+		return string(s)
+	}
+	return lang.T("pipeline." + string(s))
+}
@@ -0,0 +1,158 @@
+// Нормализация зарплат: перевод в валюту пользователя и учёт НДФЛ
+package salary
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"hhruBot/internal/hh"
+	"hhruBot/internal/i18n"
+)
+
+const (
+	BaseCurrency = "RUR"
+
+	// Ставка НДФЛ, по которой пересчитываем «до вычета» и «на руки»
+	IncomeTaxRate = 0.13
+)
+
+// Rates — сколько единиц валюты дают за один рубль (как в /dictionaries hh.ru)
+type Rates map[string]float64
+
+// DefaultRates используются, пока курсы не загружены с hh.ru
+func DefaultRates() Rates {
+	return Rates{BaseCurrency: 1}
+}
+
+// Preference — в какой валюте и на каком базисе пользователь видит зарплаты
+type Preference struct {
+	Currency string
+	Net      bool
+	Lang     i18n.Lang // язык подписей «от», «до», «на руки»
+}
+
+// DefaultPreference — рубли на руки
+func DefaultPreference() Preference {
+	return Preference{Currency: BaseCurrency, Net: true}
+}
+
+// Converter переводит зарплаты hh.ru в предпочтения пользователя
+type Converter struct {
+	Rates Rates
+	Pref  Preference
+}
+
+// Range — нормализованная вилка; нулевое значение границы означает «не указана»
+type Range struct {
+	From float64
+	To   float64
+}
+
+// NormalizeCurrency приводит пользовательский ввод к коду валюты hh.ru
+func NormalizeCurrency(code string) string {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	switch code {
+	case "RUB", "₽", "РУБ":
+		return BaseCurrency
+	}
+	return code
+}
+
+// Convert возвращает вилку в валюте и базисе пользователя; ok=false, если зарплата не указана
+// или курс валюты неизвестен.
+func (c Converter) Convert(s *hh.Salary) (Range, bool) {
+	if s == nil || (s.From == nil && s.To == nil) {
+		return Range{}, false
+	}
+
+	from := s.Currency
+	if from == "" {
+		from = BaseCurrency
+	}
+	fromRate, ok := c.Rates[from]
+	if !ok || fromRate == 0 {
+		return Range{}, false
+	}
+	toRate, ok := c.Rates[c.Pref.Currency]
+	if !ok || toRate == 0 {
+		return Range{}, false
+	}
+
+	factor := toRate / fromRate
+	if s.Gross != nil {
+		switch {
+		case *s.Gross && c.Pref.Net:
+			factor *= 1 - IncomeTaxRate
+		case !*s.Gross && !c.Pref.Net:
+			factor /= 1 - IncomeTaxRate
+		}
+	}
+
+	var r Range
+	if s.From != nil {
+		r.From = float64(*s.From) * factor
+	}
+	if s.To != nil {
+		r.To = float64(*s.To) * factor
+	}
+	return r, true
+}
+
+// Upper возвращает верхнюю известную границу вилки
+func (r Range) Upper() float64 {
+	if r.To > 0 {
+		return r.To
+	}
+	return r.From
+}
+
+// Format выводит вилку в виде «150 000–200 000 RUR на руки»
+func (c Converter) Format(r Range) string {
+	var amount string
+	switch {
+	case r.From > 0 && r.To > 0:
+		amount = formatAmount(r.From) + "–" + formatAmount(r.To)
+	case r.From > 0:
+		amount = c.Pref.Lang.T("salary.from", formatAmount(r.From))
+	default:
+		amount = c.Pref.Lang.T("salary.to", formatAmount(r.To))
+	}
+
+	basis := c.Pref.Lang.T("salary.gross")
+	if c.Pref.Net {
+		basis = c.Pref.Lang.T("salary.net")
+	}
+	return fmt.Sprintf("%s %s %s", amount, currencySymbol(c.Pref.Currency), basis)
+}
+
+func formatAmount(v float64) string {
+	n := int64(math.Round(v/1000) * 1000)
+	if n == 0 {
+		n = int64(math.Round(v))
+	}
+
+	digits := fmt.Sprintf("%d", n)
+	var b strings.Builder
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteRune(' ')
+		}
+		b.WriteRune(d)
+	}
+	return b.String()
+}
+
+func currencySymbol(code string) string {
+	switch code {
+	case "RUR":
+		return "₽"
+	case "USD":
+		return "$"
+	case "EUR":
+		return "€"
+	case "KZT":
+		return "₸"
+	}
+	return code
+}
@@ -0,0 +1,180 @@
+// Локальная фильтрация и оценка релевантности вакансий
+package scoring
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"hhruBot/internal/hh"
+	"hhruBot/internal/i18n"
+	"hhruBot/internal/salary"
+)
+
+const (
+	DefaultKeywordWeight = 1
+	DefaultSkillWeight   = 2
+
+	salaryMatchBonus   = 2
+	salaryBelowPenalty = 5
+)
+
+// Weighted — ключевое слово или навык с весом
+type Weighted struct {
+	Term   string
+	Weight int
+}
+
+// Profile описывает, как пользователь оценивает вакансии
+type Profile struct {
+	Positive  []Weighted
+	Negative  []Weighted
+	Skills    []Weighted
+	MinSalary int // в валюте и на базисе пользователя
+	Threshold int
+	Salary    salary.Converter
+	Lang      i18n.Lang // язык причин в Result.Reasons
+}
+
+// Result — итоговый балл вакансии и причины, по которым он начислен
+type Result struct {
+	Score   int
+	Reasons []string
+}
+
+// Scored — вакансия вместе с результатом оценки и нормализованной зарплатой
+type Scored struct {
+	Vacancy   hh.Vacancy
+	Result    Result
+	Salary    salary.Range
+	HasSalary bool
+}
+
+// ParseWeighted разбирает строку вида "golang:3, kubernetes, -1с:5".
+// Слова с минусом попадают в negative, без веса получают defaultWeight.
+func ParseWeighted(input string, defaultWeight int) (positive, negative []Weighted, err error) {
+	for _, part := range strings.Split(input, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		isNegative := strings.HasPrefix(part, "-")
+		part = strings.TrimSpace(strings.TrimPrefix(part, "-"))
+
+		term, weight := part, defaultWeight
+		if idx := strings.LastIndex(part, ":"); idx >= 0 {
+			term = strings.TrimSpace(part[:idx])
+			weight, err = strconv.Atoi(strings.TrimSpace(part[idx+1:]))
+			if err != nil || weight <= 0 {
+				return nil, nil, i18n.Errorf("score.bad_weight", part)
+			}
+		}
+		if term == "" {
+			return nil, nil, i18n.Errorf("score.empty_term", input)
+		}
+
+		w := Weighted{Term: strings.ToLower(term), Weight: weight}
+		if isNegative {
+			negative = append(negative, w)
+		} else {
+			positive = append(positive, w)
+		}
+	}
+	return positive, negative, nil
+}
+
+// Empty сообщает, что пользователь не настроил оценку и фильтровать нечего
+func (p Profile) Empty() bool {
+	return len(p.Positive) == 0 && len(p.Negative) == 0 && len(p.Skills) == 0 && p.MinSalary == 0
+}
+
+// NeedsDetails сообщает, нужны ли ключевые навыки из полной карточки вакансии
+func (p Profile) NeedsDetails() bool {
+	return len(p.Skills) > 0
+}
+
+// Score оценивает вакансию по названию, сниппету, навыкам и зарплате
+func (p Profile) Score(v hh.Vacancy) Result {
+	var res Result
+
+	title := strings.ToLower(v.Name)
+	snippet := strings.ToLower(v.SnippetText())
+
+	for _, w := range p.Positive {
+		switch {
+		case strings.Contains(title, w.Term):
+			res.add(w.Weight*2, p.Lang.T("score.in_title", w.Term))
+		case strings.Contains(snippet, w.Term):
+			res.add(w.Weight, p.Lang.T("score.in_snippet", w.Term))
+		}
+	}
+
+	for _, w := range p.Negative {
+		if strings.Contains(title, w.Term) || strings.Contains(snippet, w.Term) {
+			res.add(-w.Weight, p.Lang.T("score.unwanted", w.Term))
+		}
+	}
+
+	if len(p.Skills) > 0 {
+		skills := make(map[string]bool, len(v.KeySkills))
+		for _, s := range v.KeySkills {
+			skills[strings.ToLower(s.Name)] = true
+		}
+		for _, w := range p.Skills {
+			if skills[w.Term] {
+				res.add(w.Weight, p.Lang.T("score.skill", w.Term))
+			}
+		}
+	}
+
+	if p.MinSalary > 0 {
+		if r, ok := p.Salary.Convert(v.Salary); ok {
+			if r.Upper() >= float64(p.MinSalary) {
+				res.add(salaryMatchBonus, p.Lang.T("score.salary", p.Salary.Format(r)))
+			} else {
+				res.add(-salaryBelowPenalty, p.Lang.T("score.salary_below", p.MinSalary))
+			}
+		}
+	}
+
+	return res
+}
+
+// Passes сообщает, проходит ли результат порог пользователя
+func (p Profile) Passes(r Result) bool {
+	return r.Score >= p.Threshold
+}
+
+// Rank оценивает вакансии, отбрасывает не прошедшие порог и сортирует
+// по убыванию балла, а при равном балле — по нормализованной зарплате
+func (p Profile) Rank(vacancies []hh.Vacancy) []Scored {
+	ranked := make([]Scored, 0, len(vacancies))
+	for _, v := range vacancies {
+		r := p.Score(v)
+		if !p.Passes(r) {
+			continue
+		}
+		sv := Scored{Vacancy: v, Result: r}
+		sv.Salary, sv.HasSalary = p.Salary.Convert(v.Salary)
+		ranked = append(ranked, sv)
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].Result.Score != ranked[j].Result.Score {
+			return ranked[i].Result.Score > ranked[j].Result.Score
+		}
+		return ranked[i].Salary.Upper() > ranked[j].Salary.Upper()
+	})
+	return ranked
+}
+
+func (r *Result) add(points int, reason string) {
+	r.Score += points
+	sign := "+"
+	if points < 0 {
+		sign = ""
+	}
+	r.Reasons = append(r.Reasons, fmt.Sprintf("%s (%s%d)", reason, sign, points))
+}
@@ -0,0 +1,89 @@
+// HTTP-сервер бота: метрики, проверки здоровья и другие обработчики
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Check — проверка готовности; nil означает «готово»
+type Check func() error
+
+type Server struct {
+	addr        string
+	readTimeout time.Duration
+	mux         *http.ServeMux
+
+	mu     sync.Mutex
+	checks map[string]Check
+}
+
+// New создаёт сервер с эндпоинтами /healthz и /readyz
+func New(addr string, readTimeout time.Duration) *Server {
+	s := &Server{
+		addr:        addr,
+		readTimeout: readTimeout,
+		mux:         http.NewServeMux(),
+		checks:      make(map[string]Check),
+	}
+	s.mux.HandleFunc("/healthz", s.healthz)
+	s.mux.HandleFunc("/readyz", s.readyz)
+	return s
+}
+
+// Handle регистрирует обработчик на общем мультиплексоре
+func (s *Server) Handle(pattern string, handler http.Handler) {
+	s.mux.Handle(pattern, handler)
+}
+
+// AddReadinessCheck добавляет проверку, которую выполняет /readyz
+func (s *Server) AddReadinessCheck(name string, check Check) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.checks[name] = check
+}
+
+// Start запускает сервер; блокирует вызывающую горутину
+func (s *Server) Start() error {
+	srv := &http.Server{
+		Addr:              s.addr,
+		Handler:           s.mux,
+		ReadHeaderTimeout: s.readTimeout,
+	}
+	slog.Info("HTTP-сервер слушает", "addr", s.addr)
+	return srv.ListenAndServe()
+}
+
+// healthz отвечает, пока процесс жив
+func (s *Server) healthz(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("ok\n"))
+}
+
+// readyz выполняет все проверки готовности и возвращает 503, если хотя бы одна не прошла
+func (s *Server) readyz(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	checks := make(map[string]Check, len(s.checks))
+	for name, c := range s.checks {
+		checks[name] = c
+	}
+	s.mu.Unlock()
+
+	results := make(map[string]string, len(checks))
+	status := http.StatusOK
+	for name, check := range checks {
+		if err := check(); err != nil {
+			results[name] = err.Error()
+			status = http.StatusServiceUnavailable
+		} else {
+			results[name] = "ok"
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(results)
+}
@@ -0,0 +1,48 @@
+package sources
+
+import (
+	"hhruBot/internal/hh"
+)
+
+// hhSource — API hh.ru
+type hhSource struct {
+	client *hh.Client
+}
+
+func NewHH(client *hh.Client) Source {
+	return hhSource{client: client}
+}
+
+func (s hhSource) Name() string { return HH }
+
+func (s hhSource) Search(q Query) ([]Vacancy, error) {
+	items, err := s.client.GetVacancies(q.Tags, q.Cities, q.From)
+	if err != nil {
+		return nil, err
+	}
+	for i := range items {
+		normalizeHH(&items[i])
+	}
+	return items, nil
+}
+
+func (s hhSource) Details(id string) (*Vacancy, error) {
+	v, err := s.client.GetVacancy(id)
+	if err != nil {
+		return nil, err
+	}
+	normalizeHH(v)
+	return v, nil
+}
+
+func (s hhSource) Area(city string) (string, bool) {
+	id := hh.CityToAreaID(city)
+	return id, id != ""
+}
+
+func normalizeHH(v *Vacancy) {
+	v.Source = HH
+	if v.URL == "" {
+		v.URL = "https://hh.ru/vacancy/" + v.Id
+	}
+}
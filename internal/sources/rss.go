@@ -0,0 +1,168 @@
+package sources
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// rss — ленты вакансий RSS 2.0 и Atom (например, Хабр Карьера).
+// В адресе ленты {query} заменяется на теги поиска; вакансии без тегов
+// в названии или описании отбрасываются. Фильтра по городу у лент нет.
+type rss struct {
+	feeds     []string
+	userAgent string
+	client    *http.Client
+}
+
+func NewRSS(feeds []string, userAgent string, timeout time.Duration) Source {
+	return rss{feeds: feeds, userAgent: userAgent, client: &http.Client{Timeout: timeout}}
+}
+
+// feed описывает сразу RSS (channel/item) и Atom (entry)
+type feed struct {
+	Items   []feedItem `xml:"channel>item"`
+	Entries []feedItem `xml:"entry"`
+}
+
+type feedItem struct {
+	Title string `xml:"title"`
+	// В RSS ссылка — текст элемента, в Atom — атрибут href
+	Links []struct {
+		Href string `xml:"href,attr"`
+		Text string `xml:",chardata"`
+	} `xml:"link"`
+	Description string `xml:"description"`
+	Summary     string `xml:"summary"`
+	Content     string `xml:"content"`
+	GUID        string `xml:"guid"`
+	ID          string `xml:"id"`
+	PubDate     string `xml:"pubDate"`
+	Published   string `xml:"published"`
+	Updated     string `xml:"updated"`
+	Author      string `xml:"author>name"`
+}
+
+func (s rss) Name() string { return RSS }
+
+func (s rss) Search(q Query) ([]Vacancy, error) {
+	query := url.QueryEscape(strings.Join(q.Tags, " "))
+
+	var result []Vacancy
+	for _, feedURL := range s.feeds {
+		items, err := s.fetch(strings.ReplaceAll(feedURL, "{query}", query))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", feedURL, err)
+		}
+		for _, item := range items {
+			v := item.normalize()
+			if published, ok := item.published(); ok && published.Before(q.From) {
+				continue
+			}
+			if !matchesTags(v.Name+" "+v.Description, q.Tags) {
+				continue
+			}
+			result = append(result, v)
+		}
+	}
+	return result, nil
+}
+
+// Details — у лент нет отдельной карточки вакансии
+func (s rss) Details(string) (*Vacancy, error) {
+	return nil, ErrUnsupported
+}
+
+// Area — ленты не фильтруются по городу
+func (s rss) Area(string) (string, bool) {
+	return "", false
+}
+
+func (s rss) fetch(feedURL string) ([]feedItem, error) {
+	req, err := http.NewRequest("GET", feedURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", s.userAgent)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+
+	var f feed
+	if err := xml.NewDecoder(resp.Body).Decode(&f); err != nil {
+		return nil, err
+	}
+	return append(f.Items, f.Entries...), nil
+}
+
+func (item feedItem) link() string {
+	for _, l := range item.Links {
+		if l.Href != "" {
+			return l.Href
+		}
+		if text := strings.TrimSpace(l.Text); text != "" {
+			return text
+		}
+	}
+	return ""
+}
+
+func (item feedItem) published() (time.Time, bool) {
+	for _, raw := range []string{item.PubDate, item.Published, item.Updated} {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+		for _, layout := range []string{time.RFC1123Z, time.RFC1123, time.RFC3339} {
+			if t, err := time.Parse(layout, raw); err == nil {
+				return t, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+func (item feedItem) normalize() Vacancy {
+	link := item.link()
+	key := item.GUID
+	if key == "" {
+		key = item.ID
+	}
+	if key == "" {
+		key = link
+	}
+	sum := sha1.Sum([]byte(key))
+
+	description := item.Description
+	if description == "" {
+		description = item.Summary
+	}
+	if description == "" {
+		description = item.Content
+	}
+
+	v := Vacancy{
+		Id:          "rss-" + hex.EncodeToString(sum[:8]),
+		Name:        plainText(item.Title),
+		URL:         link,
+		Source:      RSS,
+		Description: plainText(description),
+	}
+	if t, ok := item.published(); ok {
+		v.PublishedAt = publishedAt(t)
+	}
+	v.Employer.Name = item.Author
+	v.Snippet.Responsibility = v.Description
+	return v
+}
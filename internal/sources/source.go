@@ -0,0 +1,196 @@
+// Источники вакансий: hh.ru, SuperJob, «Работа России» и ленты RSS/Atom
+package sources
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+	"time"
+
+	"hhruBot/internal/config"
+	"hhruBot/internal/hh"
+	"hhruBot/internal/i18n"
+)
+
+// Vacancy — нормализованная вакансия. За основу взят формат hh.ru: к нему
+// приводятся ответы всех источников, поэтому оценка, дедупликация и карточки
+// работают одинаково. Источник записан в поле Source, ссылка — в URL.
+type Vacancy = hh.Vacancy
+
+// Query — параметры фонового поиска
+type Query struct {
+	Tags   []string
+	Cities []string
+	From   time.Time
+}
+
+// Source — источник вакансий
+type Source interface {
+	// Name — короткое имя источника, оно же префикс ID вакансий ("hh", "superjob", ...)
+	Name() string
+	// Search возвращает вакансии, опубликованные после q.From
+	Search(q Query) ([]Vacancy, error)
+	// Details загружает полную карточку вакансии по ID из Search
+	Details(id string) (*Vacancy, error)
+	// Area переводит название города в код региона источника
+	Area(city string) (string, bool)
+}
+
+// cities возвращает города запроса, а без них — названия регионов hh.ru по
+// умолчанию (fallbackAreas), чтобы сторонние источники искали там же, где hh.ru.
+// Пока справочник регионов не загружен, названий нет — ищем по всей стране.
+func cities(q Query, fallbackAreas []string) []string {
+	if len(q.Cities) > 0 {
+		return q.Cities
+	}
+	var names []string
+	for _, id := range fallbackAreas {
+		if name := hh.AreaName(id); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// ErrUnsupported — источник не умеет выполнять операцию
+var ErrUnsupported = errors.New("источник не поддерживает операцию")
+
+// Имена источников
+const (
+	HH       = "hh"
+	SuperJob = "superjob"
+	Trudvsem = "trudvsem"
+	RSS      = "rss"
+)
+
+// Order — порядок источников в списках и при слиянии результатов
+var Order = []string{HH, SuperJob, Trudvsem, RSS}
+
+// Title — название источника для пользователя
+func Title(name string, lang i18n.Lang) string {
+	return lang.T("source." + name)
+}
+
+// Set — включённые в конфигурации источники по имени
+type Set map[string]Source
+
+// FromConfig собирает источники, включённые в конфигурации. hh.ru доступен всегда.
+func FromConfig(cfg *config.Config, hhClient *hh.Client) Set {
+	set := Set{HH: NewHH(hhClient)}
+	if cfg.Sources.SuperJobKey != "" {
+		set[SuperJob] = NewSuperJob(cfg.Sources.SuperJobKey, cfg.HH.Timeout, cfg.Search.FallbackAreas)
+	}
+	if cfg.Sources.Trudvsem {
+		set[Trudvsem] = NewTrudvsem(cfg.HH.UserAgent, cfg.HH.Timeout, cfg.Search.FallbackAreas)
+	}
+	if len(cfg.Sources.RSSFeeds) > 0 {
+		set[RSS] = NewRSS(cfg.Sources.RSSFeeds, cfg.HH.UserAgent, cfg.HH.Timeout)
+	}
+	return set
+}
+
+// Names возвращает имена доступных источников в порядке Order
+func (s Set) Names() []string {
+	var names []string
+	for _, name := range Order {
+		if _, ok := s[name]; ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Search опрашивает перечисленные источники и объединяет результаты.
+// Ошибки отдельных источников не прерывают поиск и возвращаются вместе.
+func (s Set) Search(names []string, q Query) ([]Vacancy, error) {
+	var all []Vacancy
+	var errs []error
+	for _, name := range names {
+		src, ok := s[name]
+		if !ok {
+			continue
+		}
+		items, err := src.Search(q)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+			continue
+		}
+		all = append(all, items...)
+	}
+	return all, errors.Join(errs...)
+}
+
+// Details загружает карточку вакансии из источника, указанного в ID
+func (s Set) Details(v Vacancy) (*Vacancy, error) {
+	src, ok := s[SourceOf(v)]
+	if !ok {
+		return nil, ErrUnsupported
+	}
+	return src.Details(v.Id)
+}
+
+// SourceOf возвращает имя источника вакансии; у вакансий hh.ru без пометки — "hh"
+func SourceOf(v Vacancy) string {
+	if v.Source == "" {
+		return HH
+	}
+	return v.Source
+}
+
+// Validate проверяет список источников и возвращает неизвестные имена
+func (s Set) Validate(names []string) []string {
+	var unknown []string
+	for _, name := range names {
+		if _, ok := s[name]; !ok {
+			unknown = append(unknown, name)
+		}
+	}
+	return unknown
+}
+
+var tagPattern = regexp.MustCompile(`<[^>]*>`)
+
+// plainText убирает HTML-разметку из описаний сторонних источников
+func plainText(s string) string {
+	s = html.UnescapeString(tagPattern.ReplaceAllString(s, " "))
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// intPtr возвращает указатель на сумму или nil, если она не указана
+func intPtr(n int) *int {
+	if n <= 0 {
+		return nil
+	}
+	return &n
+}
+
+// currencyCode приводит обозначение валюты к кодам hh.ru: "rub", "«руб.»" → "RUR"
+func currencyCode(s string) string {
+	s = strings.ToUpper(strings.Trim(s, " «».\""))
+	switch s {
+	case "", "RUB", "РУБ":
+		return "RUR"
+	}
+	return s
+}
+
+// publishedAt — время публикации в формате hh.ru
+func publishedAt(t time.Time) string {
+	return t.Format("2006-01-02T15:04:05-0700")
+}
+
+// matchesTags — вакансия содержит хотя бы один тег (без тегов подходит любая)
+func matchesTags(text string, tags []string) bool {
+	if len(tags) == 0 {
+		return true
+	}
+	text = strings.ToLower(text)
+	for _, tag := range tags {
+		if strings.Contains(text, strings.ToLower(tag)) {
+			return true
+		}
+	}
+	return false
+}
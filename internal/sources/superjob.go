@@ -0,0 +1,132 @@
+package sources
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"hhruBot/internal/hh"
+)
+
+const superJobAPI = "https://api.superjob.ru/2.0"
+
+// superJob — API SuperJob, нужен ключ приложения (X-Api-App-Id)
+type superJob struct {
+	key           string
+	client        *http.Client
+	fallbackAreas []string
+}
+
+func NewSuperJob(key string, timeout time.Duration, fallbackAreas []string) Source {
+	return superJob{key: key, client: &http.Client{Timeout: timeout}, fallbackAreas: fallbackAreas}
+}
+
+type sjVacancy struct {
+	Id         int    `json:"id"`
+	Profession string `json:"profession"`
+	FirmName   string `json:"firm_name"`
+	IdClient   int    `json:"id_client"`
+	Town       struct {
+		Title string `json:"title"`
+	} `json:"town"`
+	PaymentFrom   int    `json:"payment_from"`
+	PaymentTo     int    `json:"payment_to"`
+	Currency      string `json:"currency"`
+	Candidat      string `json:"candidat"`
+	Work          string `json:"work"`
+	RichText      string `json:"vacancyRichText"`
+	Link          string `json:"link"`
+	DatePublished int64  `json:"date_published"`
+}
+
+type sjResponse struct {
+	Objects []sjVacancy `json:"objects"`
+	Total   int         `json:"total"`
+}
+
+func (s superJob) Name() string { return SuperJob }
+
+// Search ищет по каждому тегу отдельно: в keyword SuperJob требует все слова
+// сразу, а теги, как на hh.ru, должны объединяться через OR
+func (s superJob) Search(q Query) ([]Vacancy, error) {
+	towns := cities(q, s.fallbackAreas)
+	if len(towns) == 0 {
+		towns = []string{""}
+	}
+
+	var result []Vacancy
+	seen := make(map[string]bool)
+	for _, tag := range q.Tags {
+		for _, town := range towns {
+			params := url.Values{}
+			params.Set("keyword", tag)
+			if town != "" {
+				params.Set("town", town)
+			}
+			params.Set("date_published_from", strconv.FormatInt(q.From.Unix(), 10))
+			params.Set("order_field", "date")
+			params.Set("order_direction", "desc")
+			params.Set("count", "20")
+
+			var data sjResponse
+			if err := getJSON(s.client, superJobAPI+"/vacancies/?"+params.Encode(), s.headers(), &data); err != nil {
+				return nil, err
+			}
+			for _, item := range data.Objects {
+				v := item.normalize()
+				if !seen[v.Id] {
+					seen[v.Id] = true
+					result = append(result, v)
+				}
+			}
+		}
+	}
+	return result, nil
+}
+
+func (s superJob) Details(id string) (*Vacancy, error) {
+	sjID, ok := strings.CutPrefix(id, "sj-")
+	if !ok {
+		return nil, ErrUnsupported
+	}
+	var item sjVacancy
+	if err := getJSON(s.client, fmt.Sprintf("%s/vacancies/%s/", superJobAPI, sjID), s.headers(), &item); err != nil {
+		return nil, err
+	}
+	v := item.normalize()
+	return &v, nil
+}
+
+// Area — SuperJob принимает название города как есть
+func (s superJob) Area(city string) (string, bool) {
+	return city, city != ""
+}
+
+func (s superJob) headers() map[string]string {
+	return map[string]string{"X-Api-App-Id": s.key}
+}
+
+func (item sjVacancy) normalize() Vacancy {
+	v := Vacancy{
+		Id:          fmt.Sprintf("sj-%d", item.Id),
+		Name:        item.Profession,
+		PublishedAt: publishedAt(time.Unix(item.DatePublished, 0)),
+		URL:         item.Link,
+		Source:      SuperJob,
+		Description: plainText(item.RichText),
+	}
+	v.Area.Name = item.Town.Title
+	v.Employer.Name = item.FirmName
+	if item.IdClient > 0 {
+		v.Employer.Id = fmt.Sprintf("sj-%d", item.IdClient)
+	}
+	v.Snippet.Requirement = plainText(item.Candidat)
+	v.Snippet.Responsibility = plainText(item.Work)
+	if item.PaymentFrom > 0 || item.PaymentTo > 0 {
+		v.Salary = &hh.Salary{From: intPtr(item.PaymentFrom), To: intPtr(item.PaymentTo), Currency: currencyCode(item.Currency)}
+	}
+	return v
+}
@@ -0,0 +1,177 @@
+package sources
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"hhruBot/internal/hh"
+)
+
+const trudvsemAPI = "https://opendata.trudvsem.ru/api/v1"
+
+// Коды регионов «Работы России» для крупных городов. Поиск идёт по региону целиком.
+var trudvsemRegions = map[string]string{
+	"москва":           "7700000000000",
+	"санкт-петербург":  "7800000000000",
+	"новосибирск":      "5400000000000",
+	"екатеринбург":     "6600000000000",
+	"казань":           "1600000000000",
+	"нижний новгород":  "5200000000000",
+	"краснодар":        "2300000000000",
+	"самара":           "6300000000000",
+	"челябинск":        "7400000000000",
+	"ростов-на-дону":   "6100000000000",
+	"омск":             "5500000000000",
+	"уфа":              "0200000000000",
+	"пермь":            "5900000000000",
+	"воронеж":          "3600000000000",
+	"красноярск":       "2400000000000",
+	"калининград":      "3900000000000",
+	"томск":            "7000000000000",
+	"иркутск":          "3800000000000",
+	"владивосток":      "2500000000000",
+	"ярославль":        "7600000000000",
+	"тюмень":           "7200000000000",
+	"саратов":          "6400000000000",
+	"волгоград":        "3400000000000",
+	"ижевск":           "1800000000000",
+	"севастополь":      "9200000000000",
+	"симферополь":      "9100000000000",
+	"нижнекамск":       "1600000000000",
+	"набережные челны": "1600000000000",
+}
+
+// trudvsem — открытый API «Работа России» (trudvsem.ru), ключ не нужен
+type trudvsem struct {
+	userAgent     string
+	client        *http.Client
+	fallbackAreas []string
+}
+
+func NewTrudvsem(userAgent string, timeout time.Duration, fallbackAreas []string) Source {
+	return trudvsem{userAgent: userAgent, client: &http.Client{Timeout: timeout}, fallbackAreas: fallbackAreas}
+}
+
+type tvVacancy struct {
+	Id     string `json:"id"`
+	Name   string `json:"job-name"`
+	Region struct {
+		Name string `json:"name"`
+	} `json:"region"`
+	Company struct {
+		Name string `json:"name"`
+		Code string `json:"companycode"`
+	} `json:"company"`
+	SalaryMin   float64 `json:"salary_min"`
+	SalaryMax   float64 `json:"salary_max"`
+	Currency    string  `json:"currency"`
+	Duty        string  `json:"duty"`
+	Requirement struct {
+		Qualification string `json:"qualification"`
+	} `json:"requirement"`
+	URL          string `json:"vac_url"`
+	CreationDate string `json:"creation-date"`
+}
+
+type tvResponse struct {
+	Results struct {
+		Vacancies []struct {
+			Vacancy tvVacancy `json:"vacancy"`
+		} `json:"vacancies"`
+	} `json:"results"`
+}
+
+func (s trudvsem) Name() string { return Trudvsem }
+
+// Search, как и у SuperJob, ищет по каждому тегу отдельно, чтобы теги
+// объединялись через OR, а без городов — в регионах по умолчанию
+func (s trudvsem) Search(q Query) ([]Vacancy, error) {
+	regions := make(map[string]bool)
+	for _, city := range cities(q, s.fallbackAreas) {
+		if code, ok := s.Area(city); ok {
+			regions[code] = true
+		}
+	}
+	paths := []string{"/vacancies"}
+	if len(regions) > 0 {
+		paths = nil
+		for code := range regions {
+			paths = append(paths, "/vacancies/region/"+code)
+		}
+	}
+
+	var result []Vacancy
+	seen := make(map[string]bool)
+	for _, tag := range q.Tags {
+		params := url.Values{}
+		params.Set("text", tag)
+		params.Set("modifiedFrom", q.From.UTC().Format("2006-01-02T15:04:05Z"))
+		params.Set("limit", "50")
+		params.Set("offset", "0")
+
+		for _, path := range paths {
+			var data tvResponse
+			if err := getJSON(s.client, trudvsemAPI+path+"?"+params.Encode(), s.headers(), &data); err != nil {
+				return nil, err
+			}
+			for _, item := range data.Results.Vacancies {
+				v := item.Vacancy.normalize()
+				if !seen[v.Id] {
+					seen[v.Id] = true
+					result = append(result, v)
+				}
+			}
+		}
+	}
+	return result, nil
+}
+
+// Details — ID вакансии имеет вид "tv-<код компании>/<ID>", оба нужны API
+func (s trudvsem) Details(id string) (*Vacancy, error) {
+	path, ok := strings.CutPrefix(id, "tv-")
+	if !ok || !strings.Contains(path, "/") {
+		return nil, ErrUnsupported
+	}
+	var data tvResponse
+	if err := getJSON(s.client, trudvsemAPI+"/vacancies/vacancy/"+path, s.headers(), &data); err != nil {
+		return nil, err
+	}
+	if len(data.Results.Vacancies) == 0 {
+		return nil, hh.ErrNotFound
+	}
+	v := data.Results.Vacancies[0].Vacancy.normalize()
+	return &v, nil
+}
+
+func (s trudvsem) Area(city string) (string, bool) {
+	code, ok := trudvsemRegions[strings.ToLower(strings.TrimSpace(city))]
+	return code, ok
+}
+
+func (s trudvsem) headers() map[string]string {
+	return map[string]string{"User-Agent": s.userAgent}
+}
+
+func (item tvVacancy) normalize() Vacancy {
+	v := Vacancy{
+		Id:          fmt.Sprintf("tv-%s/%s", item.Company.Code, item.Id),
+		Name:        item.Name,
+		URL:         item.URL,
+		Source:      Trudvsem,
+		Description: plainText(item.Duty),
+	}
+	if t, err := time.Parse("2006-01-02", item.CreationDate); err == nil {
+		v.PublishedAt = publishedAt(t)
+	}
+	v.Area.Name = item.Region.Name
+	v.Employer.Name = item.Company.Name
+	v.Snippet.Requirement = plainText(item.Requirement.Qualification)
+	v.Snippet.Responsibility = plainText(item.Duty)
+	if item.SalaryMin > 0 || item.SalaryMax > 0 {
+		v.Salary = &hh.Salary{From: intPtr(int(item.SalaryMin)), To: intPtr(int(item.SalaryMax)), Currency: currencyCode(item.Currency)}
+	}
+	return v
+}
@@ -0,0 +1,188 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// === Резервное копирование ===
+//
+// Ключи выгружаются в переносимом виде — тип, значение и момент истечения, —
+// а не командой DUMP, формат которой зависит от версии Redis.
+
+// Типы значений Entry
+const (
+	TypeString = "string"
+	TypeList   = "list"
+	TypeSet    = "set"
+	TypeHash   = "hash"
+	TypeZSet   = "zset"
+)
+
+// Entry — один ключ хранилища. Заполнено только поле, соответствующее Type.
+type Entry struct {
+	Key      string            `json:"key"`
+	Type     string            `json:"type"`
+	String   string            `json:"string,omitempty"`
+	List     []string          `json:"list,omitempty"`
+	Set      []string          `json:"set,omitempty"`
+	Hash     map[string]string `json:"hash,omitempty"`
+	ZSet     []ZMember         `json:"zset,omitempty"`
+	ExpireAt *time.Time        `json:"expire_at,omitempty"`
+}
+
+type ZMember struct {
+	Member string  `json:"member"`
+	Score  float64 `json:"score"`
+}
+
+// Mergeable сообщает, можно ли объединить значение с уже существующим ключом:
+// множества и хеши дополняются, строки и списки — нет
+func (e Entry) Mergeable() bool {
+	return e.Type == TypeSet || e.Type == TypeHash || e.Type == TypeZSet
+}
+
+// Entries обходит все ключи базы. Ключи, истёкшие во время обхода, пропускаются.
+func (s *Storage) Entries(fn func(Entry) error) error {
+	iter := s.client.Scan(s.ctx, 0, "*", 500).Iterator()
+	for iter.Next(s.ctx) {
+		e, err := s.readEntry(iter.Val())
+		if err == redis.Nil {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", iter.Val(), err)
+		}
+		if err := fn(*e); err != nil {
+			return err
+		}
+	}
+	return iter.Err()
+}
+
+func (s *Storage) readEntry(key string) (*Entry, error) {
+	typ, err := s.client.Type(s.ctx, key).Result()
+	if err != nil {
+		return nil, err
+	}
+	e := Entry{Key: key, Type: typ}
+	switch typ {
+	case TypeString:
+		e.String, err = s.client.Get(s.ctx, key).Result()
+	case TypeList:
+		e.List, err = s.client.LRange(s.ctx, key, 0, -1).Result()
+	case TypeSet:
+		e.Set, err = s.client.SMembers(s.ctx, key).Result()
+	case TypeHash:
+		e.Hash, err = s.client.HGetAll(s.ctx, key).Result()
+	case TypeZSet:
+		var members []redis.Z
+		members, err = s.client.ZRangeWithScores(s.ctx, key, 0, -1).Result()
+		for _, m := range members {
+			e.ZSet = append(e.ZSet, ZMember{Member: fmt.Sprint(m.Member), Score: m.Score})
+		}
+	case "none":
+		return nil, redis.Nil
+	default:
+		return nil, fmt.Errorf("неподдерживаемый тип ключа %q", typ)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	ttl, err := s.client.PTTL(s.ctx, key).Result()
+	if err != nil {
+		return nil, err
+	}
+	if ttl > 0 {
+		at := time.Now().Add(ttl).Truncate(time.Millisecond)
+		e.ExpireAt = &at
+	}
+	return &e, nil
+}
+
+// KeyExists проверяет, есть ли ключ в базе
+func (s *Storage) KeyExists(key string) (bool, error) {
+	n, err := s.client.Exists(s.ctx, key).Result()
+	return n == 1, err
+}
+
+// CountKeys возвращает число ключей в базе
+func (s *Storage) CountKeys() (int64, error) {
+	return s.client.DBSize(s.ctx).Result()
+}
+
+// Flush удаляет все ключи базы, выбранной в redis.db
+func (s *Storage) Flush() error {
+	return s.client.FlushDB(s.ctx).Err()
+}
+
+// PutEntry записывает ключ. Без merge прежнее значение заменяется целиком.
+// С merge существующий ключ не перезаписывается: в множества и хеши
+// добавляются только недостающие элементы, строки и списки остаются как есть.
+func (s *Storage) PutEntry(e Entry, merge bool) error {
+	exists, err := s.KeyExists(e.Key)
+	if err != nil {
+		return err
+	}
+	if merge && exists && !e.Mergeable() {
+		return nil
+	}
+
+	pipe := s.client.TxPipeline()
+	if !merge {
+		pipe.Del(s.ctx, e.Key)
+	}
+	switch e.Type {
+	case TypeString:
+		pipe.Set(s.ctx, e.Key, e.String, 0)
+	case TypeList:
+		if len(e.List) > 0 {
+			pipe.RPush(s.ctx, e.Key, toAny(e.List)...)
+		}
+	case TypeSet:
+		if len(e.Set) > 0 {
+			pipe.SAdd(s.ctx, e.Key, toAny(e.Set)...)
+		}
+	case TypeHash:
+		for field, value := range e.Hash {
+			if merge {
+				pipe.HSetNX(s.ctx, e.Key, field, value)
+			} else {
+				pipe.HSet(s.ctx, e.Key, field, value)
+			}
+		}
+	case TypeZSet:
+		members := make([]redis.Z, len(e.ZSet))
+		for i, m := range e.ZSet {
+			members[i] = redis.Z{Member: m.Member, Score: m.Score}
+		}
+		if len(members) > 0 {
+			if merge {
+				pipe.ZAddNX(s.ctx, e.Key, members...)
+			} else {
+				pipe.ZAdd(s.ctx, e.Key, members...)
+			}
+		}
+	default:
+		return fmt.Errorf("%s: неподдерживаемый тип ключа %q", e.Key, e.Type)
+	}
+	// Срок жизни ставим только новым ключам: у существующего он свой
+	if e.ExpireAt != nil && !(merge && exists) {
+		pipe.PExpireAt(s.ctx, e.Key, *e.ExpireAt)
+	}
+	if _, err := pipe.Exec(s.ctx); err != nil {
+		return fmt.Errorf("%s: %w", e.Key, err)
+	}
+	return nil
+}
+
+func toAny(values []string) []any {
+	result := make([]any, len(values))
+	for i, v := range values {
+		result[i] = v
+	}
+	return result
+}
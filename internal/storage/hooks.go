@@ -0,0 +1,37 @@
+package storage
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"hhruBot/internal/metrics"
+)
+
+// latencyHook замеряет длительность команд Redis для метрик
+type latencyHook struct{}
+
+func (latencyHook) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return next(ctx, network, addr)
+	}
+}
+
+func (latencyHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		start := time.Now()
+		err := next(ctx, cmd)
+		metrics.RedisLatency.Observe(time.Since(start).Seconds(), cmd.Name())
+		return err
+	}
+}
+
+func (latencyHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		start := time.Now()
+		err := next(ctx, cmds)
+		metrics.RedisLatency.Observe(time.Since(start).Seconds(), "pipeline")
+		return err
+	}
+}
@@ -0,0 +1,70 @@
+package storage
+
+import (
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// === Миграции ===
+//
+// Номер последней применённой миграции хранится в schema:version. Миграции
+// применяются по порядку и должны быть идемпотентны: если одна упала,
+// повторный запуск начнёт с неё же.
+
+const schemaVersionKey = "schema:version"
+
+// Migration — одно изменение формата данных
+type Migration struct {
+	Version int
+	Name    string
+	Up      func(s *Storage) error
+}
+
+// migrations — список по возрастанию версий, новые дописываются в конец.
+// Пока формат данных не менялся, список пуст и хранилище сразу актуально.
+var migrations = []Migration{}
+
+// SchemaVersion возвращает номер последней применённой миграции
+func (s *Storage) SchemaVersion() (int, error) {
+	v, err := s.client.Get(s.ctx, schemaVersionKey).Int()
+	if err == redis.Nil {
+		return 0, nil
+	}
+	return v, err
+}
+
+// PendingMigrations — миграции, которые ещё не применены
+func (s *Storage) PendingMigrations() ([]Migration, error) {
+	current, err := s.SchemaVersion()
+	if err != nil {
+		return nil, err
+	}
+	var pending []Migration
+	for _, m := range migrations {
+		if m.Version > current {
+			pending = append(pending, m)
+		}
+	}
+	return pending, nil
+}
+
+// Migrate применяет миграции по порядку; before вызывается перед каждой
+func (s *Storage) Migrate(before func(Migration)) error {
+	pending, err := s.PendingMigrations()
+	if err != nil {
+		return err
+	}
+	for _, m := range pending {
+		if before != nil {
+			before(m)
+		}
+		if err := m.Up(s); err != nil {
+			return fmt.Errorf("миграция %d (%s): %w", m.Version, m.Name, err)
+		}
+		if err := s.client.Set(s.ctx, schemaVersionKey, m.Version, 0).Err(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -2,19 +2,28 @@ package storage
 
 import (
 	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
+	"os"
+	"sort"
 	"strconv"
-	"time"
 	"strings"
+	"time"
 
 	"github.com/redis/go-redis/v9"
 	"hhruBot/internal/config"
+	"hhruBot/internal/logging"
 )
 
 type Storage struct {
-	client *redis.Client
-	ctx    context.Context
+	client  *redis.Client
+	ctx     context.Context
+	seenTTL time.Duration // сколько помним отправленные вакансии и их отпечатки
 }
 
 func NewStorage(cfg *config.Config) *Storage {
@@ -24,32 +33,41 @@ func NewStorage(cfg *config.Config) *Storage {
 		DB:       cfg.RedisDB,
 	})
 
+	rdb.AddHook(latencyHook{})
+
 	ctx := context.Background()
 	if err := rdb.Ping(ctx).Err(); err != nil {
-		log.Fatal("Не удалось подключиться к Redis:", err)
+		slog.Error("не удалось подключиться к Redis", logging.Err(err))
+		os.Exit(1)
 	}
 
-	fmt.Println("✅ Успешное подключение к Redis")
+	slog.Info("успешное подключение к Redis", "addr", cfg.RedisAddr)
+
+	return &Storage{client: rdb, ctx: ctx, seenTTL: cfg.Search.SeenTTL}
+}
 
-	return &Storage{client: rdb, ctx: ctx}
+// Ping проверяет соединение с Redis (для /readyz)
+func (s *Storage) Ping() error {
+	return s.client.Ping(s.ctx).Err()
 }
 
 // === Вакансии ===
 
-func (s *Storage) AlreadySeen(vacancyID int) bool {
-	key := "vacancy:" + strconv.Itoa(vacancyID)
+// ID вакансий hh.ru — числа, у других источников есть префикс: "sj-123", "rss-ab12…"
+func (s *Storage) AlreadySeen(vacancyID string) bool {
+	key := "vacancy:" + vacancyID
 	exists, err := s.client.Exists(s.ctx, key).Result()
 	if err != nil {
-		log.Printf("Redis Exists error: %v", err)
+		slog.Warn("redis exists", logging.KeyVacancyID, vacancyID, logging.Err(err))
 		return false
 	}
 	return exists == 1
 }
 
-func (s *Storage) MarkAsSeen(vacancyID int) {
-	key := "vacancy:" + strconv.Itoa(vacancyID)
-	if err := s.client.Set(s.ctx, key, "1", 7*24*time.Hour).Err(); err != nil {
-		log.Printf("Redis Set error: %v", err)
+func (s *Storage) MarkAsSeen(vacancyID string) {
+	key := "vacancy:" + vacancyID
+	if err := s.client.Set(s.ctx, key, "1", s.seenTTL).Err(); err != nil {
+		slog.Warn("redis set", logging.KeyVacancyID, vacancyID, logging.Err(err))
 	}
 }
 
@@ -90,6 +108,11 @@ func (s *Storage) GetLastChecked(chatID int64) (time.Time, error) {
 	return time.Unix(unixTs, 0), nil
 }
 
+func (s *Storage) ResetLastChecked(chatID int64) error {
+	key := fmt.Sprintf("user:%d:last_checked", chatID)
+	return s.client.Del(s.ctx, key).Err()
+}
+
 // === Пользователи ===
 
 func (s *Storage) AddUser(chatID int64) error {
@@ -129,6 +152,7 @@ func (s *Storage) IsUserPaused(chatID int64) (bool, error) {
 	}
 	return exists == 1, nil
 }
+
 // Пометить пользователя как disabled (например, заблокировал бота).
 func (s *Storage) DisableUser(chatID int64) error {
 	key := fmt.Sprintf("user:%d:disabled", chatID)
@@ -139,6 +163,15 @@ func (s *Storage) DisableUser(chatID int64) error {
 	return s.client.SRem(s.ctx, "users", strconv.FormatInt(chatID, 10)).Err()
 }
 
+// EnableUser снимает отметку disabled и возвращает пользователя в множество users
+func (s *Storage) EnableUser(chatID int64) error {
+	key := fmt.Sprintf("user:%d:disabled", chatID)
+	if err := s.client.Del(s.ctx, key).Err(); err != nil {
+		return err
+	}
+	return s.AddUser(chatID)
+}
+
 func (s *Storage) IsUserDisabled(chatID int64) (bool, error) {
 	key := fmt.Sprintf("user:%d:disabled", chatID)
 	exists, err := s.client.Exists(s.ctx, key).Result()
@@ -148,32 +181,1204 @@ func (s *Storage) IsUserDisabled(chatID int64) (bool, error) {
 	return exists == 1, nil
 }
 func (s *Storage) GetActiveUsers() ([]int64, error) {
-    keys, err := s.client.Keys(s.ctx, "user:*:paused").Result()
-    if err != nil {
-        return nil, err
-    }
-
-    var activeUsers []int64
-    for _, key := range keys {
-        paused, err := s.client.Get(s.ctx, key).Result()
-        if err != nil && err != redis.Nil {
-            continue
-        }
-        if paused == "1" {
-            continue
-        }
-
-        // key example: user:12345:paused → extract 12345
-        parts := strings.Split(key, ":")
-        if len(parts) < 2 {
-            continue
-        }
-        chatID, err := strconv.ParseInt(parts[1], 10, 64)
-        if err != nil {
-            continue
-        }
-        activeUsers = append(activeUsers, chatID)
-    }
-
-    return activeUsers, nil
+	keys, err := s.client.Keys(s.ctx, "user:*:paused").Result()
+	if err != nil {
+		return nil, err
+	}
+
+	var activeUsers []int64
+	for _, key := range keys {
+		paused, err := s.client.Get(s.ctx, key).Result()
+		if err != nil && err != redis.Nil {
+			continue
+		}
+		if paused == "1" {
+			continue
+		}
+
+		// key example: user:12345:paused → extract 12345
+		parts := strings.Split(key, ":")
+		if len(parts) < 2 {
+			continue
+		}
+		chatID, err := strconv.ParseInt(parts[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		activeUsers = append(activeUsers, chatID)
+	}
+
+	return activeUsers, nil
+}
+
+// === Курсы валют ===
+
+const currencyRatesKey = "currency:rates"
+
+func (s *Storage) SetCurrencyRates(rates map[string]float64, ttl time.Duration) error {
+	data, err := json.Marshal(rates)
+	if err != nil {
+		return err
+	}
+	return s.client.Set(s.ctx, currencyRatesKey, data, ttl).Err()
+}
+
+func (s *Storage) GetCurrencyRates() (map[string]float64, error) {
+	data, err := s.client.Get(s.ctx, currencyRatesKey).Bytes()
+	if err != nil {
+		return nil, err
+	}
+	var rates map[string]float64
+	if err := json.Unmarshal(data, &rates); err != nil {
+		return nil, err
+	}
+	return rates, nil
+}
+
+// === Отпечатки вакансий (поиск перепубликаций) ===
+
+func (s *Storage) GetFingerprint(chatID int64, fingerprint string) (string, bool) {
+	return s.getFingerprint(chatID, fmt.Sprintf("user:%d:fp:%s", chatID, fingerprint))
+}
+
+func (s *Storage) MarkFingerprint(chatID int64, fingerprint, snippet string) {
+	s.markFingerprint(chatID, fmt.Sprintf("user:%d:fp:%s", chatID, fingerprint), snippet)
+}
+
+func (s *Storage) getFingerprint(chatID int64, key string) (string, bool) {
+	snippet, err := s.client.Get(s.ctx, key).Result()
+	if err != nil {
+		if err != redis.Nil {
+			logging.Chat(chatID).Warn("redis get", logging.Err(err))
+		}
+		return "", false
+	}
+	return snippet, true
+}
+
+func (s *Storage) markFingerprint(chatID int64, key, snippet string) {
+	if err := s.client.Set(s.ctx, key, snippet, s.seenTTL).Err(); err != nil {
+		logging.Chat(chatID).Warn("redis set", logging.Err(err))
+	}
+}
+
+// === Вакансии отслеживаемых работодателей ===
+//
+// Работодатели присылают каждую новую вакансию, даже если она уже пришла
+// другому чату или этому же чату по тегам, поэтому отправленные вакансии и
+// отпечатки для них помним отдельно, в пределах чата.
+
+func (s *Storage) EmployerVacancySeen(chatID int64, vacancyID string) bool {
+	exists, err := s.client.Exists(s.ctx, fmt.Sprintf("user:%d:employer_seen:%s", chatID, vacancyID)).Result()
+	if err != nil {
+		logging.Chat(chatID).Warn("redis exists", logging.KeyVacancyID, vacancyID, logging.Err(err))
+		return false
+	}
+	return exists == 1
+}
+
+func (s *Storage) MarkEmployerVacancySeen(chatID int64, vacancyID string) {
+	key := fmt.Sprintf("user:%d:employer_seen:%s", chatID, vacancyID)
+	if err := s.client.Set(s.ctx, key, "1", s.seenTTL).Err(); err != nil {
+		logging.Chat(chatID).Warn("redis set", logging.KeyVacancyID, vacancyID, logging.Err(err))
+	}
+}
+
+func (s *Storage) GetEmployerFingerprint(chatID int64, fingerprint string) (string, bool) {
+	return s.getFingerprint(chatID, fmt.Sprintf("user:%d:employer_fp:%s", chatID, fingerprint))
+}
+
+func (s *Storage) MarkEmployerFingerprint(chatID int64, fingerprint, snippet string) {
+	s.markFingerprint(chatID, fmt.Sprintf("user:%d:employer_fp:%s", chatID, fingerprint), snippet)
+}
+
+// === Отслеживаемые вакансии ===
+
+// TrackedVacancy — последний известный снимок вакансии, за которой следит пользователь
+type TrackedVacancy struct {
+	Id             string    `json:"id"`
+	Name           string    `json:"name"`
+	SalaryFrom     int       `json:"salary_from,omitempty"`
+	SalaryTo       int       `json:"salary_to,omitempty"`
+	SalaryCurrency string    `json:"salary_currency,omitempty"`
+	Description    string    `json:"description"`
+	Archived       bool      `json:"archived"`
+	CheckedAt      time.Time `json:"checked_at"`
+}
+
+// VacancyChange — запись в истории изменений отслеживаемой вакансии
+type VacancyChange struct {
+	At      time.Time `json:"at"`
+	Kind    string    `json:"kind"`
+	Details string    `json:"details"`
+}
+
+func (s *Storage) TrackVacancy(chatID int64, v TrackedVacancy) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if err := s.client.SAdd(s.ctx, fmt.Sprintf("user:%d:tracked", chatID), v.Id).Err(); err != nil {
+		return err
+	}
+	return s.client.Set(s.ctx, fmt.Sprintf("user:%d:tracked:%s", chatID, v.Id), data, 0).Err()
+}
+
+func (s *Storage) UntrackVacancy(chatID int64, vacancyID string) error {
+	if err := s.client.SRem(s.ctx, fmt.Sprintf("user:%d:tracked", chatID), vacancyID).Err(); err != nil {
+		return err
+	}
+	return s.client.Del(s.ctx,
+		fmt.Sprintf("user:%d:tracked:%s", chatID, vacancyID),
+		fmt.Sprintf("user:%d:tracked:%s:history", chatID, vacancyID),
+	).Err()
+}
+
+func (s *Storage) GetTrackedIDs(chatID int64) ([]string, error) {
+	return s.client.SMembers(s.ctx, fmt.Sprintf("user:%d:tracked", chatID)).Result()
+}
+
+func (s *Storage) GetTrackedVacancy(chatID int64, vacancyID string) (*TrackedVacancy, error) {
+	data, err := s.client.Get(s.ctx, fmt.Sprintf("user:%d:tracked:%s", chatID, vacancyID)).Bytes()
+	if err != nil {
+		return nil, err
+	}
+	var v TrackedVacancy
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+func (s *Storage) AddVacancyChange(chatID int64, vacancyID string, change VacancyChange) error {
+	data, err := json.Marshal(change)
+	if err != nil {
+		return err
+	}
+	return s.client.RPush(s.ctx, fmt.Sprintf("user:%d:tracked:%s:history", chatID, vacancyID), data).Err()
+}
+
+func (s *Storage) GetVacancyChanges(chatID int64, vacancyID string) ([]VacancyChange, error) {
+	items, err := s.client.LRange(s.ctx, fmt.Sprintf("user:%d:tracked:%s:history", chatID, vacancyID), 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+	changes := make([]VacancyChange, 0, len(items))
+	for _, item := range items {
+		var c VacancyChange
+		if err := json.Unmarshal([]byte(item), &c); err == nil {
+			changes = append(changes, c)
+		}
+	}
+	return changes, nil
+}
+
+// === Отслеживаемые работодатели ===
+
+func (s *Storage) WatchEmployer(chatID int64, employerID, name string) error {
+	return s.client.HSet(s.ctx, fmt.Sprintf("user:%d:employers", chatID), employerID, name).Err()
+}
+
+func (s *Storage) UnwatchEmployer(chatID int64, employerID string) error {
+	return s.client.HDel(s.ctx, fmt.Sprintf("user:%d:employers", chatID), employerID).Err()
+}
+
+// GetWatchedEmployers возвращает ID работодателей с их названиями
+func (s *Storage) GetWatchedEmployers(chatID int64) (map[string]string, error) {
+	return s.client.HGetAll(s.ctx, fmt.Sprintf("user:%d:employers", chatID)).Result()
+}
+
+func (s *Storage) SetEmployersLastChecked(chatID int64, t time.Time) error {
+	key := fmt.Sprintf("user:%d:employers_last_checked", chatID)
+	return s.client.Set(s.ctx, key, t.Unix(), 0).Err()
+}
+
+func (s *Storage) GetEmployersLastChecked(chatID int64) (time.Time, error) {
+	key := fmt.Sprintf("user:%d:employers_last_checked", chatID)
+	unixTs, err := s.client.Get(s.ctx, key).Int64()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(unixTs, 0), nil
+}
+
+// === Сохранённые вакансии и воронка откликов ===
+
+// SavedVacancy — закладка пользователя со статусом в воронке и заметками
+type SavedVacancy struct {
+	Id       string         `json:"id"`
+	Name     string         `json:"name"`
+	Employer string         `json:"employer"`
+	Area     string         `json:"area"`
+	Status   string         `json:"status"`
+	Notes    []Note         `json:"notes,omitempty"`
+	History  []StatusChange `json:"history"`
+	SavedAt  time.Time      `json:"saved_at"`
+}
+
+type Note struct {
+	At   time.Time `json:"at"`
+	Text string    `json:"text"`
+}
+
+type StatusChange struct {
+	At     time.Time `json:"at"`
+	Status string    `json:"status"`
+}
+
+func (s *Storage) SaveVacancy(chatID int64, v SavedVacancy) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return s.client.HSet(s.ctx, fmt.Sprintf("user:%d:saved", chatID), v.Id, data).Err()
+}
+
+func (s *Storage) GetSavedVacancy(chatID int64, vacancyID string) (*SavedVacancy, error) {
+	data, err := s.client.HGet(s.ctx, fmt.Sprintf("user:%d:saved", chatID), vacancyID).Bytes()
+	if err != nil {
+		return nil, err
+	}
+	var v SavedVacancy
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+func (s *Storage) GetSavedVacancies(chatID int64) ([]SavedVacancy, error) {
+	items, err := s.client.HGetAll(s.ctx, fmt.Sprintf("user:%d:saved", chatID)).Result()
+	if err != nil {
+		return nil, err
+	}
+	saved := make([]SavedVacancy, 0, len(items))
+	for _, item := range items {
+		var v SavedVacancy
+		if err := json.Unmarshal([]byte(item), &v); err == nil {
+			saved = append(saved, v)
+		}
+	}
+	sort.Slice(saved, func(i, j int) bool { return saved[i].SavedAt.After(saved[j].SavedAt) })
+	return saved, nil
+}
+
+func (s *Storage) DeleteSavedVacancy(chatID int64, vacancyID string) error {
+	return s.client.HDel(s.ctx, fmt.Sprintf("user:%d:saved", chatID), vacancyID).Err()
+}
+
+// === Сохранённые поиски ===
+
+// SavedSearch — дополнительный поиск пользователя со своими тегами, городами и источниками.
+// Основной поиск (теги и города из /tags и /city) в хранилище отдельно не записывается.
+type SavedSearch struct {
+	Id      string   `json:"id"`
+	Name    string   `json:"name"`
+	Tags    []string `json:"tags"`
+	Cities  []string `json:"cities"`
+	Sources []string `json:"sources"`
+
+	// Каналы доставки (ID из /channels); пусто — каналы пользователя по умолчанию
+	Channels []string `json:"channels,omitempty"`
+}
+
+func (s *Storage) SaveSearch(chatID int64, search SavedSearch) error {
+	data, err := json.Marshal(search)
+	if err != nil {
+		return err
+	}
+	return s.client.HSet(s.ctx, fmt.Sprintf("user:%d:searches", chatID), search.Id, data).Err()
+}
+
+func (s *Storage) GetSavedSearch(chatID int64, searchID string) (*SavedSearch, error) {
+	data, err := s.client.HGet(s.ctx, fmt.Sprintf("user:%d:searches", chatID), searchID).Bytes()
+	if err != nil {
+		return nil, err
+	}
+	var search SavedSearch
+	if err := json.Unmarshal(data, &search); err != nil {
+		return nil, err
+	}
+	return &search, nil
+}
+
+// GetSavedSearches возвращает поиски пользователя, отсортированные по названию
+func (s *Storage) GetSavedSearches(chatID int64) ([]SavedSearch, error) {
+	items, err := s.client.HGetAll(s.ctx, fmt.Sprintf("user:%d:searches", chatID)).Result()
+	if err != nil {
+		return nil, err
+	}
+	searches := make([]SavedSearch, 0, len(items))
+	for _, item := range items {
+		var search SavedSearch
+		if err := json.Unmarshal([]byte(item), &search); err == nil {
+			searches = append(searches, search)
+		}
+	}
+	sort.Slice(searches, func(i, j int) bool { return searches[i].Name < searches[j].Name })
+	return searches, nil
+}
+
+func (s *Storage) DeleteSavedSearch(chatID int64, searchID string) error {
+	n, err := s.client.HDel(s.ctx, fmt.Sprintf("user:%d:searches", chatID), searchID).Result()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return redis.Nil
+	}
+	return nil
+}
+
+// === Таймеры (напоминания) ===
+//
+// Таймеры лежат в сортированном множестве timers по времени срабатывания,
+// поэтому переживают перезапуск бота.
+
+const (
+	timersKey     = "timers"
+	timersDataKey = "timers:data"
+)
+
+// Timer — разовое событие, которое нужно выполнить в момент Due
+type Timer struct {
+	Id        string    `json:"id"`
+	ChatID    int64     `json:"chat_id"`
+	Kind      string    `json:"kind"`
+	VacancyID string    `json:"vacancy_id,omitempty"`
+	Text      string    `json:"text,omitempty"`
+	Due       time.Time `json:"due"`
+}
+
+func (s *Storage) ScheduleTimer(t Timer) error {
+	data, err := json.Marshal(t)
+	if err != nil {
+		return err
+	}
+	pipe := s.client.TxPipeline()
+	pipe.HSet(s.ctx, timersDataKey, t.Id, data)
+	pipe.ZAdd(s.ctx, timersKey, redis.Z{Score: float64(t.Due.Unix()), Member: t.Id})
+	pipe.SAdd(s.ctx, fmt.Sprintf("user:%d:timers", t.ChatID), t.Id)
+	_, err = pipe.Exec(s.ctx)
+	return err
+}
+
+// DueTimers возвращает ID таймеров, срок которых наступил к моменту now
+func (s *Storage) DueTimers(now time.Time) ([]string, error) {
+	return s.client.ZRangeByScore(s.ctx, timersKey, &redis.ZRangeBy{
+		Min: "-inf",
+		Max: strconv.FormatInt(now.Unix(), 10),
+	}).Result()
+}
+
+// ClaimTimer забирает таймер на выполнение. Возвращает false, если его уже
+// забрал другой обработчик или таймер отменён.
+func (s *Storage) ClaimTimer(id string) (*Timer, bool, error) {
+	removed, err := s.client.ZRem(s.ctx, timersKey, id).Result()
+	if err != nil || removed == 0 {
+		return nil, false, err
+	}
+	t, err := s.GetTimer(id)
+	if err != nil {
+		return nil, false, err
+	}
+	s.client.HDel(s.ctx, timersDataKey, id)
+	s.client.SRem(s.ctx, fmt.Sprintf("user:%d:timers", t.ChatID), id)
+	return t, true, nil
+}
+
+// PendingTimers возвращает число запланированных таймеров
+func (s *Storage) PendingTimers() int64 {
+	n, _ := s.client.ZCard(s.ctx, timersKey).Result()
+	return n
+}
+
+func (s *Storage) GetTimer(id string) (*Timer, error) {
+	data, err := s.client.HGet(s.ctx, timersDataKey, id).Bytes()
+	if err != nil {
+		return nil, err
+	}
+	var t Timer
+	if err := json.Unmarshal(data, &t); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// CancelTimer отменяет таймер пользователя. Возвращает false, если у пользователя
+// такого таймера нет: чужие таймеры не трогаем.
+func (s *Storage) CancelTimer(chatID int64, id string) (bool, error) {
+	owned, err := s.client.SIsMember(s.ctx, fmt.Sprintf("user:%d:timers", chatID), id).Result()
+	if err != nil || !owned {
+		return false, err
+	}
+	pipe := s.client.TxPipeline()
+	pipe.ZRem(s.ctx, timersKey, id)
+	pipe.HDel(s.ctx, timersDataKey, id)
+	pipe.SRem(s.ctx, fmt.Sprintf("user:%d:timers", chatID), id)
+	_, err = pipe.Exec(s.ctx)
+	return err == nil, err
+}
+
+// GetUserTimers возвращает запланированные таймеры пользователя по возрастанию срока
+func (s *Storage) GetUserTimers(chatID int64) ([]Timer, error) {
+	ids, err := s.client.SMembers(s.ctx, fmt.Sprintf("user:%d:timers", chatID)).Result()
+	if err != nil {
+		return nil, err
+	}
+	timers := make([]Timer, 0, len(ids))
+	for _, id := range ids {
+		if t, err := s.GetTimer(id); err == nil {
+			timers = append(timers, *t)
+		}
+	}
+	sort.Slice(timers, func(i, j int) bool { return timers[i].Due.Before(timers[j].Due) })
+	return timers, nil
+}
+
+// === Разовый поиск (/find) ===
+
+// FindState — запрос пользователя для листания результатов /find. Хранится
+// отдельно для каждого сообщения с результатами, чтобы новый /find не менял,
+// что листают кнопки под прежними.
+type FindState struct {
+	Query string `json:"query"`
+	Area  string `json:"area,omitempty"`
+	City  string `json:"city,omitempty"`
+}
+
+func (s *Storage) SetFindState(chatID int64, messageID int, state FindState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return s.client.Set(s.ctx, fmt.Sprintf("user:%d:find:%d", chatID, messageID), data, 24*time.Hour).Err()
+}
+
+func (s *Storage) GetFindState(chatID int64, messageID int) (*FindState, error) {
+	data, err := s.client.Get(s.ctx, fmt.Sprintf("user:%d:find:%d", chatID, messageID)).Bytes()
+	if err != nil {
+		return nil, err
+	}
+	var state FindState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// === Inline-режим ===
+
+func (s *Storage) GetInlineCache(query string) ([]byte, error) {
+	return s.client.Get(s.ctx, "inline:cache:"+query).Bytes()
+}
+
+func (s *Storage) SetInlineCache(query string, data []byte, ttl time.Duration) error {
+	return s.client.Set(s.ctx, "inline:cache:"+query, data, ttl).Err()
+}
+
+// AllowInlineQuery ограничивает частоту inline-запросов одного пользователя:
+// возвращает false, если предыдущий запрос был меньше interval назад.
+func (s *Storage) AllowInlineQuery(userID int64, interval time.Duration) bool {
+	key := fmt.Sprintf("inline:throttle:%d", userID)
+	ok, err := s.client.SetNX(s.ctx, key, "1", interval).Result()
+	if err != nil {
+		slog.Warn("redis setnx", "user_id", userID, logging.Err(err))
+		return true
+	}
+	return ok
+}
+
+// === Статистика и обслуживание ===
+
+const maintenanceKey = "maintenance"
+
+// IncrStat увеличивает почасовой счётчик name (хранится двое суток)
+func (s *Storage) IncrStat(name string) {
+	key := fmt.Sprintf("stats:%s:%s", name, time.Now().UTC().Format("2006010215"))
+	pipe := s.client.TxPipeline()
+	pipe.Incr(s.ctx, key)
+	pipe.Expire(s.ctx, key, 48*time.Hour)
+	if _, err := pipe.Exec(s.ctx); err != nil {
+		slog.Warn("redis incr", "stat", name, logging.Err(err))
+	}
+}
+
+// SumStats суммирует счётчик name за последние hours часов, включая текущий
+func (s *Storage) SumStats(name string, hours int) (int64, error) {
+	now := time.Now().UTC()
+	keys := make([]string, 0, hours)
+	for i := 0; i < hours; i++ {
+		keys = append(keys, fmt.Sprintf("stats:%s:%s", name, now.Add(-time.Duration(i)*time.Hour).Format("2006010215")))
+	}
+
+	values, err := s.client.MGet(s.ctx, keys...).Result()
+	if err != nil {
+		return 0, err
+	}
+	var total int64
+	for _, v := range values {
+		if str, ok := v.(string); ok {
+			n, _ := strconv.ParseInt(str, 10, 64)
+			total += n
+		}
+	}
+	return total, nil
+}
+
+// CountUserFlags считает пользователей с флагом user:<id>:<flag> (paused, disabled)
+func (s *Storage) CountUserFlags(flag string) (int, error) {
+	var count int
+	iter := s.client.Scan(s.ctx, 0, "user:*:"+flag, 100).Iterator()
+	for iter.Next(s.ctx) {
+		count++
+	}
+	return count, iter.Err()
+}
+
+func (s *Storage) SetMaintenance(on bool) error {
+	if on {
+		return s.client.Set(s.ctx, maintenanceKey, "1", 0).Err()
+	}
+	return s.client.Del(s.ctx, maintenanceKey).Err()
+}
+
+func (s *Storage) IsMaintenance() bool {
+	exists, err := s.client.Exists(s.ctx, maintenanceKey).Result()
+	return err == nil && exists == 1
+}
+
+// === Рассылка ===
+
+func (s *Storage) SetPendingBroadcast(adminID int64, text string) error {
+	return s.client.Set(s.ctx, fmt.Sprintf("admin:%d:broadcast", adminID), text, time.Hour).Err()
+}
+
+// TakePendingBroadcast возвращает и удаляет подготовленную рассылку
+func (s *Storage) TakePendingBroadcast(adminID int64) (string, error) {
+	return s.client.GetDel(s.ctx, fmt.Sprintf("admin:%d:broadcast", adminID)).Result()
+}
+
+// === Каналы доставки ===
+
+// Channel — канал доставки вакансий пользователя: почта, вебхук Slack/Discord, комната Matrix
+type Channel struct {
+	Id      string    `json:"id"`
+	Kind    string    `json:"kind"`
+	Address string    `json:"address"`
+	Secret  string    `json:"secret,omitempty"`
+	Pending bool      `json:"pending,omitempty"` // адрес ещё не подтверждён кодом
+	AddedAt time.Time `json:"added_at"`
+}
+
+func (s *Storage) AddChannel(chatID int64, ch Channel) error {
+	data, err := json.Marshal(ch)
+	if err != nil {
+		return err
+	}
+	return s.client.HSet(s.ctx, fmt.Sprintf("user:%d:channels", chatID), ch.Id, data).Err()
+}
+
+// GetChannels возвращает каналы пользователя в порядке добавления
+func (s *Storage) GetChannels(chatID int64) ([]Channel, error) {
+	items, err := s.client.HGetAll(s.ctx, fmt.Sprintf("user:%d:channels", chatID)).Result()
+	if err != nil {
+		return nil, err
+	}
+	channels := make([]Channel, 0, len(items))
+	for _, item := range items {
+		var ch Channel
+		if err := json.Unmarshal([]byte(item), &ch); err == nil {
+			channels = append(channels, ch)
+		}
+	}
+	sort.Slice(channels, func(i, j int) bool { return channels[i].AddedAt.Before(channels[j].AddedAt) })
+	return channels, nil
+}
+
+// ErrTooManyAttempts — код подтверждения сброшен после неверных попыток
+var ErrTooManyAttempts = errors.New("слишком много неверных попыток")
+
+// SetChannelCode сохраняет хеш кода подтверждения канала на ttl. Сам код
+// не хранится: он не должен попасть ни в /mydata, ни к тому, кто читает Redis.
+// Счётчик неверных попыток лежит в том же ключе и сбрасывается с новым кодом.
+func (s *Storage) SetChannelCode(chatID int64, channelID, code string, ttl time.Duration) error {
+	key := fmt.Sprintf("user:%d:channel_code:%s", chatID, channelID)
+	pipe := s.client.TxPipeline()
+	pipe.Del(s.ctx, key)
+	pipe.HSet(s.ctx, key, "hash", channelCodeHash(channelID, code))
+	pipe.Expire(s.ctx, key, ttl)
+	_, err := pipe.Exec(s.ctx)
+	return err
+}
+
+// ConfirmChannel снимает с канала отметку Pending, если код совпал.
+// Код одноразовый: после подтверждения он удаляется. После maxAttempts
+// неверных попыток код тоже удаляется и возвращается ErrTooManyAttempts.
+func (s *Storage) ConfirmChannel(chatID int64, channelID, code string, maxAttempts int64) (bool, error) {
+	key := fmt.Sprintf("user:%d:channel_code:%s", chatID, channelID)
+	want, err := s.client.HGet(s.ctx, key, "hash").Result()
+	if err == redis.Nil {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if subtle.ConstantTimeCompare([]byte(want), []byte(channelCodeHash(channelID, code))) != 1 {
+		fails, err := s.client.HIncrBy(s.ctx, key, "fails", 1).Result()
+		if err != nil {
+			return false, err
+		}
+		if fails >= maxAttempts {
+			if err := s.client.Del(s.ctx, key).Err(); err != nil {
+				return false, err
+			}
+			return false, ErrTooManyAttempts
+		}
+		return false, nil
+	}
+
+	data, err := s.client.HGet(s.ctx, fmt.Sprintf("user:%d:channels", chatID), channelID).Result()
+	if err != nil {
+		return false, err
+	}
+	var ch Channel
+	if err := json.Unmarshal([]byte(data), &ch); err != nil {
+		return false, err
+	}
+	ch.Pending = false
+	if err := s.AddChannel(chatID, ch); err != nil {
+		return false, err
+	}
+	return true, s.client.Del(s.ctx, key).Err()
+}
+
+func channelCodeHash(channelID, code string) string {
+	sum := sha256.Sum256([]byte(channelID + ":" + code))
+	return hex.EncodeToString(sum[:])
+}
+
+// AllowChannelCode ограничивает частоту писем с кодами подтверждения одного чата
+func (s *Storage) AllowChannelCode(chatID int64, interval time.Duration) (bool, error) {
+	return s.client.SetNX(s.ctx, fmt.Sprintf("user:%d:channel_code_throttle", chatID), "1", interval).Result()
+}
+
+func (s *Storage) DeleteChannel(chatID int64, channelID string) error {
+	n, err := s.client.HDel(s.ctx, fmt.Sprintf("user:%d:channels", chatID), channelID).Result()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return redis.Nil
+	}
+	return nil
+}
+
+// === История совпадений и ленты ===
+
+// HistoryEntry — снимок вакансии, которая совпала с поиском и была доставлена
+type HistoryEntry struct {
+	Id         string          `json:"id"`
+	Name       string          `json:"name"`
+	Employer   string          `json:"employer,omitempty"`
+	Area       string          `json:"area,omitempty"`
+	Salary     string          `json:"salary,omitempty"`
+	URL        string          `json:"url"`
+	Source     string          `json:"source,omitempty"`
+	Summary    string          `json:"summary,omitempty"`
+	Score      int             `json:"score,omitempty"`
+	SearchID   string          `json:"search_id,omitempty"`
+	SearchName string          `json:"search_name,omitempty"`
+	MatchedAt  time.Time       `json:"matched_at"`
+	Actions    []HistoryAction `json:"actions,omitempty"`
+}
+
+// HistoryAction — что пользователь сделал с отправленной вакансией
+type HistoryAction struct {
+	At     time.Time `json:"at"`
+	Action string    `json:"action"`
+}
+
+// AddHistory добавляет вакансию в историю поиска, оставляя последние limit записей
+func (s *Storage) AddHistory(chatID int64, searchID string, entry HistoryEntry, limit int) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	key := fmt.Sprintf("user:%d:history:%s", chatID, searchID)
+	pipe := s.client.TxPipeline()
+	pipe.LPush(s.ctx, key, data)
+	pipe.LTrim(s.ctx, key, 0, int64(limit)-1)
+	_, err = pipe.Exec(s.ctx)
+	return err
+}
+
+// GetHistory возвращает историю поиска, новые записи первыми
+func (s *Storage) GetHistory(chatID int64, searchID string) ([]HistoryEntry, error) {
+	items, err := s.client.LRange(s.ctx, fmt.Sprintf("user:%d:history:%s", chatID, searchID), 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]HistoryEntry, 0, len(items))
+	for _, item := range items {
+		var e HistoryEntry
+		if err := json.Unmarshal([]byte(item), &e); err == nil {
+			entries = append(entries, e)
+		}
+	}
+	return entries, nil
+}
+
+func (s *Storage) DeleteHistory(chatID int64, searchID string) error {
+	return s.client.Del(s.ctx, fmt.Sprintf("user:%d:history:%s", chatID, searchID)).Err()
+}
+
+// GetFeedToken возвращает токен лент пользователя; пусто — токен ещё не выдан
+func (s *Storage) GetFeedToken(chatID int64) (string, error) {
+	token, err := s.client.Get(s.ctx, fmt.Sprintf("user:%d:feed_token", chatID)).Result()
+	if err == redis.Nil {
+		return "", nil
+	}
+	return token, err
+}
+
+// SetFeedToken выдаёт пользователю новый токен; прежний перестаёт действовать
+func (s *Storage) SetFeedToken(chatID int64, token string) error {
+	old, err := s.GetFeedToken(chatID)
+	if err != nil {
+		return err
+	}
+	pipe := s.client.TxPipeline()
+	if old != "" {
+		pipe.Del(s.ctx, "feed:"+old)
+	}
+	pipe.Set(s.ctx, "feed:"+token, chatID, 0)
+	pipe.Set(s.ctx, fmt.Sprintf("user:%d:feed_token", chatID), token, 0)
+	_, err = pipe.Exec(s.ctx)
+	return err
+}
+
+// FeedOwner возвращает chatID владельца токена
+func (s *Storage) FeedOwner(token string) (int64, error) {
+	return s.client.Get(s.ctx, "feed:"+token).Int64()
+}
+
+// === Токены HTTP API ===
+//
+// Храним только SHA-256 токена: сам токен пользователь видит один раз в /apitoken
+
+// SetAPITokenHash выдаёт пользователю новый токен API; прежний перестаёт действовать
+func (s *Storage) SetAPITokenHash(chatID int64, hash string) error {
+	key := fmt.Sprintf("user:%d:api_token", chatID)
+	old, err := s.client.Get(s.ctx, key).Result()
+	if err != nil && err != redis.Nil {
+		return err
+	}
+	pipe := s.client.TxPipeline()
+	if old != "" {
+		pipe.Del(s.ctx, "apitoken:"+old)
+	}
+	pipe.Set(s.ctx, "apitoken:"+hash, chatID, 0)
+	pipe.Set(s.ctx, key, hash, 0)
+	_, err = pipe.Exec(s.ctx)
+	return err
+}
+
+// RevokeAPIToken отзывает токен API пользователя
+func (s *Storage) RevokeAPIToken(chatID int64) error {
+	key := fmt.Sprintf("user:%d:api_token", chatID)
+	hash, err := s.client.GetDel(s.ctx, key).Result()
+	if err != nil {
+		return err
+	}
+	return s.client.Del(s.ctx, "apitoken:"+hash).Err()
+}
+
+// APITokenOwner возвращает chatID владельца токена по его хешу
+func (s *Storage) APITokenOwner(hash string) (int64, error) {
+	return s.client.Get(s.ctx, "apitoken:"+hash).Int64()
+}
+
+// === Сессии веб-кабинета ===
+
+// Сессии пользователя дополнительно лежат в user:<id>:sessions, чтобы при
+// удалении данных выйти из всех браузеров
+func (s *Storage) CreateSession(sessionID string, chatID int64, ttl time.Duration) error {
+	key := fmt.Sprintf("user:%d:sessions", chatID)
+	pipe := s.client.TxPipeline()
+	pipe.Set(s.ctx, "session:"+sessionID, chatID, ttl)
+	pipe.SAdd(s.ctx, key, sessionID)
+	pipe.Expire(s.ctx, key, ttl)
+	_, err := pipe.Exec(s.ctx)
+	return err
+}
+
+// SessionOwner возвращает chatID пользователя, вошедшего в сессию
+func (s *Storage) SessionOwner(sessionID string) (int64, error) {
+	return s.client.Get(s.ctx, "session:"+sessionID).Int64()
+}
+
+func (s *Storage) DeleteSession(sessionID string) error {
+	chatID, err := s.client.GetDel(s.ctx, "session:"+sessionID).Int64()
+	if err == redis.Nil {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return s.client.SRem(s.ctx, fmt.Sprintf("user:%d:sessions", chatID), sessionID).Err()
+}
+
+// === Данные пользователя (/mydata, /deleteme) ===
+//
+// Почти всё о чате лежит под user:<id>:*. Вне этого префикса — таймеры
+// напоминаний, обратные индексы токенов и сессий, флаг рассылки и троттлинг
+// inline-запросов: их находим по ссылкам из user:<id>:* и удаляем отдельно.
+// Статистика рынка и счётчики /stats обезличены и к чату не привязаны.
+
+// UserData — всё, что хранится о чате
+type UserData struct {
+	ChatID     int64          `json:"chat_id"`
+	ExportedAt time.Time      `json:"exported_at"`
+	Keys       map[string]any `json:"keys"` // ключ без префикса user:<id>: → значение
+	Timers     []Timer        `json:"timers"`
+}
+
+// ExportUser собирает все ключи чата. JSON-значения (поиски, закладки,
+// история) встраиваются как есть, остальные — строками.
+func (s *Storage) ExportUser(chatID int64) (*UserData, error) {
+	keys, err := s.userKeys(chatID)
+	if err != nil {
+		return nil, err
+	}
+	prefix := fmt.Sprintf("user:%d:", chatID)
+	data := &UserData{ChatID: chatID, ExportedAt: time.Now(), Keys: make(map[string]any, len(keys))}
+	for _, key := range keys {
+		if secretUserKey(strings.TrimPrefix(key, prefix)) {
+			continue
+		}
+		value, err := s.exportKey(key)
+		if err == redis.Nil {
+			continue // ключ истёк, пока мы читали остальные
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		data.Keys[strings.TrimPrefix(key, prefix)] = value
+	}
+	if data.Timers, err = s.GetUserTimers(chatID); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// secretUserKey — ключи, которые в выгрузку не попадают: коды подтверждения,
+// ID сессий веб-кабинета и хеш токена API дают доступ, а не описывают данные
+func secretUserKey(name string) bool {
+	return strings.HasPrefix(name, "channel_code") || name == "sessions" || name == "api_token"
+}
+
+func (s *Storage) exportKey(key string) (any, error) {
+	typ, err := s.client.Type(s.ctx, key).Result()
+	if err != nil {
+		return nil, err
+	}
+	switch typ {
+	case "string":
+		v, err := s.client.Get(s.ctx, key).Result()
+		return exportValue(v), err
+	case "list":
+		values, err := s.client.LRange(s.ctx, key, 0, -1).Result()
+		return exportValues(values), err
+	case "set":
+		values, err := s.client.SMembers(s.ctx, key).Result()
+		sort.Strings(values)
+		return exportValues(values), err
+	case "hash":
+		fields, err := s.client.HGetAll(s.ctx, key).Result()
+		result := make(map[string]any, len(fields))
+		for field, v := range fields {
+			result[field] = exportValue(v)
+		}
+		return result, err
+	case "zset":
+		members, err := s.client.ZRangeWithScores(s.ctx, key, 0, -1).Result()
+		result := make(map[string]float64, len(members))
+		for _, m := range members {
+			result[fmt.Sprint(m.Member)] = m.Score
+		}
+		return result, err
+	case "none":
+		return nil, redis.Nil
+	}
+	return nil, fmt.Errorf("неизвестный тип ключа %q", typ)
+}
+
+func exportValue(v string) any {
+	if json.Valid([]byte(v)) && (strings.HasPrefix(v, "{") || strings.HasPrefix(v, "[")) {
+		return json.RawMessage(v)
+	}
+	return v
+}
+
+func exportValues(values []string) []any {
+	result := make([]any, len(values))
+	for i, v := range values {
+		result[i] = exportValue(v)
+	}
+	return result
+}
+
+// DeleteUser удаляет все данные чата: ключи user:<id>:*, таймеры, токены лент
+// и API, сессии веб-кабинета и запись в списке пользователей
+func (s *Storage) DeleteUser(chatID int64) error {
+	prefix := fmt.Sprintf("user:%d:", chatID)
+	var related []string
+	if token, err := s.GetFeedToken(chatID); err != nil {
+		return err
+	} else if token != "" {
+		related = append(related, "feed:"+token)
+	}
+	if hash, err := s.client.Get(s.ctx, prefix+"api_token").Result(); err == nil {
+		related = append(related, "apitoken:"+hash)
+	} else if err != redis.Nil {
+		return err
+	}
+	sessions, err := s.client.SMembers(s.ctx, prefix+"sessions").Result()
+	if err != nil {
+		return err
+	}
+	for _, id := range sessions {
+		related = append(related, "session:"+id)
+	}
+	timers, err := s.client.SMembers(s.ctx, prefix+"timers").Result()
+	if err != nil {
+		return err
+	}
+	related = append(related, fmt.Sprintf("inline:throttle:%d", chatID), fmt.Sprintf("admin:%d:broadcast", chatID))
+
+	// Сначала убираем чат из users, чтобы после перезапуска для него не поднялся чекер
+	pipe := s.client.TxPipeline()
+	pipe.SRem(s.ctx, "users", strconv.FormatInt(chatID, 10))
+	if len(timers) > 0 {
+		pipe.ZRem(s.ctx, timersKey, toAny(timers)...)
+		pipe.HDel(s.ctx, timersDataKey, timers...)
+	}
+	pipe.Del(s.ctx, related...)
+	if _, err := pipe.Exec(s.ctx); err != nil {
+		return err
+	}
+
+	keys, err := s.userKeys(chatID)
+	if err != nil {
+		return err
+	}
+	for len(keys) > 0 {
+		batch := keys[:min(len(keys), 500)]
+		if err := s.client.Del(s.ctx, batch...).Err(); err != nil {
+			return err
+		}
+		keys = keys[len(batch):]
+	}
+	return nil
+}
+
+// userKeys — все ключи user:<id>:*
+func (s *Storage) userKeys(chatID int64) ([]string, error) {
+	var keys []string
+	iter := s.client.Scan(s.ctx, 0, fmt.Sprintf("user:%d:*", chatID), 500).Iterator()
+	for iter.Next(s.ctx) {
+		keys = append(keys, iter.Val())
+	}
+	sort.Strings(keys)
+	return keys, iter.Err()
+}
+
+// === Аналитика рынка (/market) ===
+//
+// Дневная статистика по запросу (тег и город): уникальные вакансии, зарплаты,
+// работодатели и ключевые навыки. Город "*" — все города вместе.
+
+// Сколько храним дневную статистику рынка
+const marketTTL = 100 * 24 * time.Hour
+
+// MarketSample — вакансия, попавшая в статистику рынка
+type MarketSample struct {
+	Tag       string
+	City      string
+	Day       time.Time
+	VacancyID string
+	Salary    float64 // рубли на руки в месяц; 0 — не указана
+	Employer  string
+	Skills    []string
+}
+
+// MarketDay — статистика запроса за один день
+type MarketDay struct {
+	Date      time.Time
+	Count     int
+	Salaries  []float64
+	Employers map[string]int
+	Skills    map[string]int
+}
+
+func marketKey(tag, city string, day time.Time) string {
+	return fmt.Sprintf("market:%s:%s:%s", tag, city, day.Format("2006-01-02"))
+}
+
+// RecordMarket учитывает вакансию один раз за день, сколько бы пользователей её ни нашли
+func (s *Storage) RecordMarket(sample MarketSample) error {
+	key := marketKey(sample.Tag, sample.City, sample.Day)
+	added, err := s.client.SAdd(s.ctx, key+":ids", sample.VacancyID).Result()
+	if err != nil || added == 0 {
+		return err
+	}
+
+	pipe := s.client.Pipeline()
+	pipe.Expire(s.ctx, key+":ids", marketTTL)
+	if sample.Salary > 0 {
+		pipe.RPush(s.ctx, key+":salaries", sample.Salary)
+		pipe.Expire(s.ctx, key+":salaries", marketTTL)
+	}
+	if sample.Employer != "" {
+		pipe.ZIncrBy(s.ctx, key+":employers", 1, sample.Employer)
+		pipe.Expire(s.ctx, key+":employers", marketTTL)
+	}
+	for _, skill := range sample.Skills {
+		pipe.ZIncrBy(s.ctx, key+":skills", 1, skill)
+	}
+	if len(sample.Skills) > 0 {
+		pipe.Expire(s.ctx, key+":skills", marketTTL)
+	}
+	_, err = pipe.Exec(s.ctx)
+	return err
+}
+
+// GetMarketDays возвращает статистику запроса за дни с from по to включительно
+func (s *Storage) GetMarketDays(tag, city string, from, to time.Time) ([]MarketDay, error) {
+	type dayCmds struct {
+		count     *redis.IntCmd
+		salaries  *redis.StringSliceCmd
+		employers *redis.ZSliceCmd
+		skills    *redis.ZSliceCmd
+	}
+
+	var dates []time.Time
+	var cmds []dayCmds
+	pipe := s.client.Pipeline()
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		key := marketKey(tag, city, day)
+		dates = append(dates, day)
+		cmds = append(cmds, dayCmds{
+			count:     pipe.SCard(s.ctx, key+":ids"),
+			salaries:  pipe.LRange(s.ctx, key+":salaries", 0, -1),
+			employers: pipe.ZRangeWithScores(s.ctx, key+":employers", 0, -1),
+			skills:    pipe.ZRangeWithScores(s.ctx, key+":skills", 0, -1),
+		})
+	}
+	if _, err := pipe.Exec(s.ctx); err != nil && err != redis.Nil {
+		return nil, err
+	}
+
+	days := make([]MarketDay, len(dates))
+	for i, c := range cmds {
+		d := MarketDay{
+			Date:      dates[i],
+			Count:     int(c.count.Val()),
+			Employers: zsliceCounts(c.employers.Val()),
+			Skills:    zsliceCounts(c.skills.Val()),
+		}
+		for _, val := range c.salaries.Val() {
+			if v, err := strconv.ParseFloat(val, 64); err == nil {
+				d.Salaries = append(d.Salaries, v)
+			}
+		}
+		days[i] = d
+	}
+	return days, nil
+}
+
+func zsliceCounts(items []redis.Z) map[string]int {
+	counts := make(map[string]int, len(items))
+	for _, z := range items {
+		if member, ok := z.Member.(string); ok {
+			counts[member] = int(z.Score)
+		}
+	}
+	return counts
+}
+
+// === История отправленных вакансий ===
+//
+// user:<id>:sent — сортированное множество ID по времени отправки,
+// user:<id>:sent:data — снимки вакансий. Записи старше срока хранения удаляются при записи.
+
+// AddSent записывает отправленную вакансию в историю чата
+func (s *Storage) AddSent(chatID int64, entry HistoryEntry, retention time.Duration) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	key := fmt.Sprintf("user:%d:sent", chatID)
+	cutoff := strconv.FormatInt(time.Now().Add(-retention).Unix(), 10)
+	expired, err := s.client.ZRangeByScore(s.ctx, key, &redis.ZRangeBy{Min: "-inf", Max: "(" + cutoff}).Result()
+	if err != nil {
+		return err
+	}
+
+	pipe := s.client.TxPipeline()
+	if len(expired) > 0 {
+		pipe.HDel(s.ctx, key+":data", expired...)
+		pipe.ZRemRangeByScore(s.ctx, key, "-inf", "("+cutoff)
+	}
+	pipe.ZAdd(s.ctx, key, redis.Z{Score: float64(entry.MatchedAt.Unix()), Member: entry.Id})
+	pipe.HSet(s.ctx, key+":data", entry.Id, data)
+	_, err = pipe.Exec(s.ctx)
+	return err
+}
+
+// GetSent возвращает историю отправленных вакансий, новые первыми
+func (s *Storage) GetSent(chatID int64) ([]HistoryEntry, error) {
+	key := fmt.Sprintf("user:%d:sent", chatID)
+	ids, err := s.client.ZRevRange(s.ctx, key, 0, -1).Result()
+	if err != nil || len(ids) == 0 {
+		return nil, err
+	}
+	items, err := s.client.HMGet(s.ctx, key+":data", ids...).Result()
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]HistoryEntry, 0, len(items))
+	for _, item := range items {
+		data, ok := item.(string)
+		if !ok {
+			continue
+		}
+		var e HistoryEntry
+		if err := json.Unmarshal([]byte(data), &e); err == nil {
+			entries = append(entries, e)
+		}
+	}
+	return entries, nil
+}
+
+// AddSentAction дописывает действие пользователя к вакансии из истории.
+// Вакансии не из истории (например, найденные через /find) пропускаем.
+func (s *Storage) AddSentAction(chatID int64, vacancyID string, action HistoryAction) error {
+	key := fmt.Sprintf("user:%d:sent:data", chatID)
+	data, err := s.client.HGet(s.ctx, key, vacancyID).Bytes()
+	if err == redis.Nil {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var e HistoryEntry
+	if err := json.Unmarshal(data, &e); err != nil {
+		return err
+	}
+	e.Actions = append(e.Actions, action)
+	if data, err = json.Marshal(e); err != nil {
+		return err
+	}
+	return s.client.HSet(s.ctx, key, vacancyID, data).Err()
 }
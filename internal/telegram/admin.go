@@ -0,0 +1,224 @@
+package telegram
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"hhruBot/internal/i18n"
+	"hhruBot/internal/logging"
+)
+
+// Пауза между сообщениями рассылки: лимит Telegram — около 30 сообщений в секунду
+const broadcastDelay = 40 * time.Millisecond
+
+// Команды администратора. Сравниваются целиком: иначе /pauseall в группе или
+// у обычного пользователя ушла бы в /pause.
+var adminCommands = map[string]bool{
+	"/stats":     true,
+	"/broadcast": true,
+	"/user":      true,
+	"/pauseall":  true,
+	"/resumeall": true,
+}
+
+func (b *Bot) isAdmin(userID int64) bool {
+	return b.Admins[userID]
+}
+
+// handleAdminCommand обрабатывает команды администратора. Возвращает false,
+// если это не админская команда и её нужно обработать как обычную.
+func (b *Bot) handleAdminCommand(chatID int64, text string) bool {
+	l := b.lang(chatID)
+	switch commandName(text) {
+	case "/stats":
+		b.sendStats(chatID)
+
+	case "/broadcast":
+		msgText := strings.TrimSpace(strings.TrimPrefix(text, "/broadcast"))
+		if msgText == "" {
+			b.SendMessage(chatID, l.T("broadcast.usage"))
+			return true
+		}
+		if err := b.Storage.SetPendingBroadcast(chatID, msgText); err != nil {
+			b.SendMessage(chatID, l.T("broadcast.prepare_error"))
+			return true
+		}
+		users, _ := b.Storage.GetUsers()
+		msg := tgbotapi.NewMessage(chatID, l.T("broadcast.preview", l.N("recipients", len(users)), msgText))
+		msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(l.T("broadcast.button.send"), "broadcast:confirm"),
+			tgbotapi.NewInlineKeyboardButtonData(l.T("deleteme.button.cancel"), "broadcast:cancel"),
+		))
+		_, _ = b.send(msg)
+
+	case "/user":
+		args := strings.Fields(strings.TrimPrefix(text, "/user"))
+		if len(args) == 0 {
+			b.SendMessage(chatID, l.T("user.usage"))
+			return true
+		}
+		userID, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			b.SendMessage(chatID, l.T("user.bad_id"))
+			return true
+		}
+		switch {
+		case len(args) == 1:
+			b.SendMessage(chatID, b.describeUser(userID, l))
+		case args[1] == "reset":
+			b.resetUser(chatID, userID)
+		case args[1] == "export":
+			b.exportUser(chatID, userID)
+		case args[1] == "delete":
+			b.askDeleteUser(chatID, userID)
+		default:
+			b.SendMessage(chatID, l.T("user.unknown_action"))
+		}
+
+	case "/pauseall":
+		if err := b.Storage.SetMaintenance(true); err != nil {
+			b.SendMessage(chatID, l.T("maintenance.on_error"))
+			return true
+		}
+		b.SendMessage(chatID, l.T("maintenance.on"))
+
+	case "/resumeall":
+		if err := b.Storage.SetMaintenance(false); err != nil {
+			b.SendMessage(chatID, l.T("maintenance.off_error"))
+			return true
+		}
+		b.SendMessage(chatID, l.T("maintenance.off"))
+
+	default:
+		return false
+	}
+	return true
+}
+
+func (b *Bot) sendStats(chatID int64) {
+	l := b.lang(chatID)
+	users, _ := b.Storage.GetUsers()
+	paused, _ := b.Storage.CountUserFlags("paused")
+	disabled, _ := b.Storage.CountUserFlags("disabled")
+	checks, _ := b.Storage.SumStats("checks", 1)
+	sent, _ := b.Storage.SumStats("sent", 24)
+	requests, _ := b.Storage.SumStats("hh_requests", 24)
+	hhErrors, _ := b.Storage.SumStats("hh_errors", 24)
+
+	errorRate := 0.0
+	if requests > 0 {
+		errorRate = float64(hhErrors) / float64(requests) * 100
+	}
+
+	maintenance := l.T("stats.maintenance_off")
+	if b.Storage.IsMaintenance() {
+		maintenance = l.T("stats.maintenance_on")
+	}
+
+	checkers, employerCheckers := b.checkerCounts()
+	b.SendMessage(chatID, l.T("stats.text",
+		len(users), paused, disabled,
+		checkers, employerCheckers,
+		checks, sent, requests, hhErrors, errorRate, maintenance))
+}
+
+func (b *Bot) describeUser(userID int64, l i18n.Lang) string {
+	setting := func(key string) string {
+		val, _ := b.Storage.GetUserSetting(userID, key)
+		if val == "" {
+			return "—"
+		}
+		return val
+	}
+
+	paused, _ := b.Storage.IsUserPaused(userID)
+	disabled, _ := b.Storage.IsUserDisabled(userID)
+	lastChecked := "—"
+	if t, err := b.Storage.GetLastChecked(userID); err == nil {
+		lastChecked = t.Format(l.T("layout.datetime"))
+	}
+	tracked, _ := b.Storage.GetTrackedIDs(userID)
+	saved, _ := b.Storage.GetSavedVacancies(userID)
+	employers, _ := b.Storage.GetWatchedEmployers(userID)
+	running := b.checkerRunning(userID)
+
+	return l.T("user.info",
+		userID, setting("tags"), setting("cities"), setting("interval"),
+		paused, disabled, running, lastChecked,
+		len(tracked), len(saved), len(employers), userID, userID, userID)
+}
+
+// resetUser снимает паузу и отключение, сбрасывает время последней проверки и перезапускает чекер
+func (b *Bot) resetUser(adminChatID, userID int64) {
+	_ = b.Storage.ResumeUser(userID)
+	_ = b.Storage.EnableUser(userID)
+	_ = b.Storage.ResetLastChecked(userID)
+
+	b.startChecker(userID)
+
+	b.SendMessage(adminChatID, b.lang(adminChatID).T("user.reset_done", userID))
+}
+
+// handleBroadcastCallback подтверждает или отменяет подготовленную рассылку
+func (b *Bot) handleBroadcastCallback(cq *tgbotapi.CallbackQuery, arg string) {
+	adminID := cq.From.ID
+	if !b.isAdmin(adminID) {
+		b.answerCallback(cq.ID, "")
+		return
+	}
+	l := b.lang(cq.Message.Chat.ID)
+
+	text, err := b.Storage.TakePendingBroadcast(adminID)
+	if err != nil {
+		b.answerCallback(cq.ID, l.T("broadcast.not_found"))
+		return
+	}
+
+	b.editKeyboard(cq.Message, tgbotapi.NewInlineKeyboardMarkup())
+	if arg != "confirm" {
+		b.answerCallback(cq.ID, l.T("broadcast.cancelled"))
+		return
+	}
+	b.answerCallback(cq.ID, l.T("broadcast.started"))
+	go b.broadcast(cq.Message.Chat.ID, text)
+}
+
+// broadcast рассылает сообщение всем пользователям с учётом лимитов Telegram.
+// Тех, кто заблокировал бота, помечаем отключёнными.
+func (b *Bot) broadcast(adminChatID int64, text string) {
+	l := b.lang(adminChatID)
+	users, err := b.Storage.GetUsers()
+	if err != nil {
+		b.SendMessage(adminChatID, l.T("broadcast.users_error"))
+		return
+	}
+
+	var delivered, blocked, failed int
+	for _, chatID := range users {
+		_, err := b.send(tgbotapi.NewMessage(chatID, text))
+		var tgErr *tgbotapi.Error
+		switch {
+		case err == nil:
+			delivered++
+		case errors.As(err, &tgErr) && tgErr.Code == 403:
+			blocked++
+			_ = b.Storage.DisableUser(chatID)
+		case errors.As(err, &tgErr) && tgErr.RetryAfter > 0:
+			time.Sleep(time.Duration(tgErr.RetryAfter) * time.Second)
+			if _, err := b.send(tgbotapi.NewMessage(chatID, text)); err == nil {
+				delivered++
+			} else {
+				failed++
+			}
+		default:
+			failed++
+			logging.Chat(chatID).Warn("рассылка: не удалось отправить", logging.Err(err))
+		}
+		time.Sleep(broadcastDelay)
+	}
+
+	b.SendMessage(adminChatID, l.T("broadcast.done", delivered, blocked, failed))
+}
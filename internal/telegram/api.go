@@ -0,0 +1,344 @@
+package telegram
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"hhruBot/internal/i18n"
+	"hhruBot/internal/logging"
+	"hhruBot/internal/pipeline"
+	"hhruBot/internal/storage"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Максимальный размер тела запроса к API
+const apiMaxBody = 64 << 10
+
+type apiChatKey struct{}
+
+// searchRequest — тело POST и PATCH для поиска. В PATCH отсутствующие поля
+// не меняются, поэтому все поля — указатели.
+type searchRequest struct {
+	Name     *string   `json:"name"`
+	Tags     *[]string `json:"tags"`
+	Cities   *[]string `json:"cities"`
+	Sources  *[]string `json:"sources"`
+	Channels *[]string `json:"channels"`
+}
+
+func (r searchRequest) apply(s *storage.SavedSearch) {
+	if r.Name != nil {
+		s.Name = strings.TrimSpace(*r.Name)
+	}
+	if r.Tags != nil {
+		s.Tags = cleanList(*r.Tags, false)
+	}
+	if r.Cities != nil {
+		s.Cities = cleanList(*r.Cities, false)
+	}
+	if r.Sources != nil {
+		s.Sources = cleanList(*r.Sources, true)
+	}
+	if r.Channels != nil {
+		s.Channels = cleanList(*r.Channels, true)
+	}
+}
+
+// cleanList убирает пустые значения, как parseCSV в командах
+func cleanList(values []string, lower bool) []string {
+	var result []string
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if lower {
+			v = strings.ToLower(v)
+		}
+		if v != "" {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+type pipelineStage struct {
+	Status    string                 `json:"status"`
+	Title     string                 `json:"title"`
+	Vacancies []storage.SavedVacancy `json:"vacancies"`
+}
+
+// APIHandler — HTTP API для управления поисками. Запросы авторизуются
+// заголовком "Authorization: Bearer <токен из /apitoken>".
+func (b *Bot) APIHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/searches", b.apiListSearches)
+	mux.HandleFunc("POST /api/v1/searches", b.apiCreateSearch)
+	mux.HandleFunc("GET /api/v1/searches/{id}", b.apiGetSearch)
+	mux.HandleFunc("PATCH /api/v1/searches/{id}", b.apiUpdateSearch)
+	mux.HandleFunc("DELETE /api/v1/searches/{id}", b.apiDeleteSearch)
+	mux.HandleFunc("GET /api/v1/searches/{id}/history", b.apiSearchHistory)
+	mux.HandleFunc("GET /api/v1/status", b.apiStatus)
+	mux.HandleFunc("POST /api/v1/pause", b.apiPause)
+	mux.HandleFunc("POST /api/v1/resume", b.apiResume)
+	mux.HandleFunc("GET /api/v1/pipeline", b.apiPipeline)
+	return b.apiAuth(mux)
+}
+
+// apiAuth находит владельца токена и кладёт его chatID в контекст запроса
+func (b *Bot) apiAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// владелец ещё не известен: язык ошибок авторизации — из Accept-Language
+		l := requestLang(r)
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			writeAPIError(w, http.StatusUnauthorized, l.T("api.no_token"))
+			return
+		}
+		chatID, err := b.Storage.APITokenOwner(hashAPIToken(token))
+		if err != nil {
+			writeAPIError(w, http.StatusUnauthorized, l.T("api.bad_token"))
+			return
+		}
+		if disabled, _ := b.Storage.IsUserDisabled(chatID); disabled {
+			writeAPIError(w, http.StatusForbidden, b.lang(chatID).T("api.disabled"))
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), apiChatKey{}, chatID)))
+	})
+}
+
+func apiChatID(r *http.Request) int64 {
+	chatID, _ := r.Context().Value(apiChatKey{}).(int64)
+	return chatID
+}
+
+func (b *Bot) apiListSearches(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, loadSearches(apiChatID(r), b.Storage))
+}
+
+func (b *Bot) apiGetSearch(w http.ResponseWriter, r *http.Request) {
+	search, ok := b.apiFindSearch(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, search)
+}
+
+func (b *Bot) apiCreateSearch(w http.ResponseWriter, r *http.Request) {
+	chatID := apiChatID(r)
+	var req searchRequest
+	if !decodeJSON(w, r, &req, b.lang(chatID)) {
+		return
+	}
+	var search storage.SavedSearch
+	req.apply(&search)
+	created, err := b.createSearch(chatID, search)
+	if err != nil {
+		b.writeSearchError(w, chatID, err)
+		return
+	}
+	_ = b.Storage.AddUser(chatID)
+	writeJSON(w, http.StatusCreated, created)
+}
+
+func (b *Bot) apiUpdateSearch(w http.ResponseWriter, r *http.Request) {
+	chatID := apiChatID(r)
+	search, ok := b.apiFindSearch(w, r)
+	if !ok {
+		return
+	}
+	l := b.lang(chatID)
+	var req searchRequest
+	if !decodeJSON(w, r, &req, l) {
+		return
+	}
+	if search.Id == mainSearchID && req.Name != nil {
+		writeAPIError(w, http.StatusBadRequest, l.T("api.main_rename"))
+		return
+	}
+	req.apply(&search)
+	if err := b.updateSearch(chatID, search); err != nil {
+		b.writeSearchError(w, chatID, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, search)
+}
+
+func (b *Bot) apiDeleteSearch(w http.ResponseWriter, r *http.Request) {
+	chatID := apiChatID(r)
+	l := b.lang(chatID)
+	searchID := r.PathValue("id")
+	if searchID == mainSearchID {
+		writeAPIError(w, http.StatusBadRequest, l.T("api.main_delete"))
+		return
+	}
+	if err := b.Storage.DeleteSavedSearch(chatID, searchID); err != nil {
+		writeAPIError(w, http.StatusNotFound, l.T("api.not_found"))
+		return
+	}
+	_ = b.Storage.DeleteHistory(chatID, searchID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (b *Bot) apiSearchHistory(w http.ResponseWriter, r *http.Request) {
+	chatID := apiChatID(r)
+	search, ok := b.apiFindSearch(w, r)
+	if !ok {
+		return
+	}
+	history, err := b.Storage.GetHistory(chatID, search.Id)
+	if err != nil {
+		logging.Chat(chatID).Error("не удалось загрузить историю поиска", logging.KeySearchID, search.Id, logging.Err(err))
+		writeAPIError(w, http.StatusInternalServerError, b.lang(chatID).T("api.history_error"))
+		return
+	}
+	writeJSON(w, http.StatusOK, history)
+}
+
+func (b *Bot) apiStatus(w http.ResponseWriter, r *http.Request) {
+	paused, _ := b.Storage.IsUserPaused(apiChatID(r))
+	writeJSON(w, http.StatusOK, map[string]bool{"paused": paused})
+}
+
+func (b *Bot) apiPause(w http.ResponseWriter, r *http.Request) {
+	chatID := apiChatID(r)
+	if err := b.pauseSearch(chatID); err != nil {
+		writeAPIError(w, http.StatusInternalServerError, b.lang(chatID).T("api.pause_error"))
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]bool{"paused": true})
+}
+
+func (b *Bot) apiResume(w http.ResponseWriter, r *http.Request) {
+	chatID := apiChatID(r)
+	if _, err := b.resumeSearch(chatID); err != nil {
+		writeAPIError(w, http.StatusInternalServerError, b.lang(chatID).T("api.resume_error"))
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]bool{"paused": false})
+}
+
+func (b *Bot) apiPipeline(w http.ResponseWriter, r *http.Request) {
+	chatID := apiChatID(r)
+	l := b.lang(chatID)
+	saved, err := b.Storage.GetSavedVacancies(chatID)
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, l.T("api.pipeline_error"))
+		return
+	}
+	stages := make([]pipelineStage, 0, len(pipeline.Statuses))
+	for _, st := range pipeline.Statuses {
+		stage := pipelineStage{Status: string(st), Title: st.Title(l), Vacancies: []storage.SavedVacancy{}}
+		for _, v := range saved {
+			if v.Status == string(st) {
+				stage.Vacancies = append(stage.Vacancies, v)
+			}
+		}
+		stages = append(stages, stage)
+	}
+	writeJSON(w, http.StatusOK, stages)
+}
+
+// apiFindSearch ищет поиск из пути запроса, включая основной
+func (b *Bot) apiFindSearch(w http.ResponseWriter, r *http.Request) (storage.SavedSearch, bool) {
+	chatID := apiChatID(r)
+	searchID := r.PathValue("id")
+	for _, s := range loadSearches(chatID, b.Storage) {
+		if s.Id == searchID {
+			return s, true
+		}
+	}
+	writeAPIError(w, http.StatusNotFound, b.lang(chatID).T("api.not_found"))
+	return storage.SavedSearch{}, false
+}
+
+// sendAPIToken выдаёт новый токен API или отзывает текущий ("/apitoken revoke").
+// Токен группы уходит в личку тому, кто выполнил команду, а не всей группе.
+func (b *Bot) sendAPIToken(message *tgbotapi.Message, args string) {
+	chatID := message.Chat.ID
+	l := b.lang(chatID)
+	if strings.TrimSpace(args) == "revoke" {
+		if err := b.Storage.RevokeAPIToken(chatID); err != nil {
+			b.SendMessage(chatID, l.T("apitoken.none"))
+			return
+		}
+		b.SendMessage(chatID, l.T("apitoken.revoked"))
+		return
+	}
+
+	to, ok := privateRecipient(message)
+	if !ok {
+		b.SendMessage(chatID, l.T("private.unavailable"))
+		return
+	}
+	token := newAPIToken()
+	base := b.PublicURL
+	if base == "" {
+		base = l.T("apitoken.base_placeholder")
+	}
+	text := l.T("apitoken.issued", token, token, base)
+	if to != chatID {
+		text = l.T("private.for_chat", message.Chat.Title) + "\n\n" + text
+	}
+	// токен сохраняем, только если его удалось доставить: иначе прежний
+	// перестал бы действовать, а новый никто бы не увидел
+	if _, err := b.Api.Send(tgbotapi.NewMessage(to, text)); err != nil {
+		logging.Chat(chatID).Warn("не удалось отправить токен API", logging.Err(err))
+		b.SendMessage(chatID, l.T("private.failed"))
+		return
+	}
+	if err := b.Storage.SetAPITokenHash(chatID, hashAPIToken(token)); err != nil {
+		b.SendMessage(to, l.T("apitoken.error"))
+		return
+	}
+	if to != chatID {
+		b.SendMessage(chatID, l.T("private.sent"))
+	}
+}
+
+func (b *Bot) writeSearchError(w http.ResponseWriter, chatID int64, err error) {
+	l := b.lang(chatID)
+	var input *inputError
+	if errors.As(err, &input) {
+		writeAPIError(w, http.StatusBadRequest, l.Err(input))
+		return
+	}
+	logging.Chat(chatID).Error("ошибка API при сохранении поиска", logging.Err(err))
+	writeAPIError(w, http.StatusInternalServerError, l.Err(err))
+}
+
+func decodeJSON(w http.ResponseWriter, r *http.Request, dst any, l i18n.Lang) bool {
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, apiMaxBody))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(dst); err != nil {
+		writeAPIError(w, http.StatusBadRequest, l.T("api.bad_json", err.Error()))
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeAPIError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]string{"error": msg})
+}
+
+func hashAPIToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func newAPIToken() string {
+	buf := make([]byte, 24)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
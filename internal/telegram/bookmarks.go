@@ -0,0 +1,187 @@
+package telegram
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"hhruBot/internal/i18n"
+	"hhruBot/internal/pipeline"
+	"hhruBot/internal/storage"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Сколько карточек показываем по команде /saved
+const savedListLimit = 20
+
+// saveVacancy добавляет вакансию в закладки со статусом «Сохранено».
+// Если вакансия уже сохранена, возвращает существующую закладку.
+func (b *Bot) saveVacancy(chatID int64, vacancyID string) (*storage.SavedVacancy, error) {
+	if saved, err := b.Storage.GetSavedVacancy(chatID, vacancyID); err == nil {
+		return saved, nil
+	}
+
+	v, err := b.HHClient.GetVacancy(vacancyID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	saved := storage.SavedVacancy{
+		Id:       v.Id,
+		Name:     v.Name,
+		Employer: v.Employer.Name,
+		Area:     v.Area.Name,
+		Status:   string(pipeline.Saved),
+		History:  []storage.StatusChange{{At: now, Status: string(pipeline.Saved)}},
+		SavedAt:  now,
+	}
+	if err := b.Storage.SaveVacancy(chatID, saved); err != nil {
+		return nil, err
+	}
+	b.recordAction(chatID, vacancyID, actionSaved)
+	return &saved, nil
+}
+
+// setVacancyStatus переводит закладку в новый статус и записывает переход в историю
+func (b *Bot) setVacancyStatus(chatID int64, vacancyID string, status pipeline.Status) (*storage.SavedVacancy, error) {
+	saved, err := b.Storage.GetSavedVacancy(chatID, vacancyID)
+	if err != nil {
+		return nil, err
+	}
+	if saved.Status == string(status) {
+		return saved, nil
+	}
+
+	saved.Status = string(status)
+	saved.History = append(saved.History, storage.StatusChange{At: time.Now(), Status: string(status)})
+	if err := b.Storage.SaveVacancy(chatID, *saved); err != nil {
+		return nil, err
+	}
+	if status == pipeline.Applied {
+		b.scheduleFollowUp(chatID, vacancyID)
+	}
+	b.recordAction(chatID, vacancyID, actionStatus+string(status))
+	return saved, nil
+}
+
+// statusKeyboard — кнопки смены статуса под карточкой сохранённой вакансии
+func statusKeyboard(vacancyID string, current string, l i18n.Lang) tgbotapi.InlineKeyboardMarkup {
+	var row []tgbotapi.InlineKeyboardButton
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for _, st := range pipeline.Statuses {
+		label := st.Title(l)
+		if string(st) == current {
+			label = "✅ " + label
+		}
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(label, "status:"+vacancyID+":"+string(st)))
+		if len(row) == 3 {
+			rows = append(rows, row)
+			row = nil
+		}
+	}
+	row = append(row, tgbotapi.NewInlineKeyboardButtonData(l.T("saved.delete"), "unsave:"+vacancyID))
+	rows = append(rows, row)
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+// formatSavedCard — карточка закладки; даты в часовом поясе пользователя loc
+func formatSavedCard(v storage.SavedVacancy, l i18n.Lang, loc *time.Location) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s\n%s", v.Name, "https://hh.ru/vacancy/"+v.Id)
+	if v.Employer != "" {
+		fmt.Fprintf(&sb, "\n🏢 %s", v.Employer)
+	}
+	if v.Area != "" {
+		fmt.Fprintf(&sb, "\n🏙️ %s", v.Area)
+	}
+	sb.WriteString("\n" + l.T("saved.status", pipeline.Status(v.Status).Title(l)))
+	for _, h := range v.History {
+		fmt.Fprintf(&sb, "\n  %s — %s", h.At.In(loc).Format(l.T("layout.datetime")), pipeline.Status(h.Status).Title(l))
+	}
+	for _, n := range v.Notes {
+		fmt.Fprintf(&sb, "\n🗒 %s: %s", n.At.In(loc).Format(l.T("layout.date")), n.Text)
+	}
+	return sb.String()
+}
+
+// sendSavedList отправляет карточки закладок с клавиатурой смены статуса
+func (b *Bot) sendSavedList(chatID int64) {
+	l := b.lang(chatID)
+	saved, err := b.Storage.GetSavedVacancies(chatID)
+	if err != nil {
+		b.SendMessage(chatID, l.T("saved.error"))
+		return
+	}
+	if len(saved) == 0 {
+		b.SendMessage(chatID, l.T("saved.empty"))
+		return
+	}
+
+	if len(saved) > savedListLimit {
+		b.SendMessage(chatID, l.T("saved.limited", savedListLimit, len(saved)))
+		saved = saved[:savedListLimit]
+	}
+	for _, v := range saved {
+		msg := tgbotapi.NewMessage(chatID, formatSavedCard(v, l, b.userLocation(chatID)))
+		msg.ReplyMarkup = statusKeyboard(v.Id, v.Status, l)
+		msg.DisableWebPagePreview = true
+		_, _ = b.send(msg)
+	}
+}
+
+// sendPipeline показывает закладки, сгруппированные по статусам
+func (b *Bot) sendPipeline(chatID int64) {
+	l := b.lang(chatID)
+	saved, err := b.Storage.GetSavedVacancies(chatID)
+	if err != nil {
+		b.SendMessage(chatID, l.T("pipeline.error"))
+		return
+	}
+	if len(saved) == 0 {
+		b.SendMessage(chatID, l.T("pipeline.empty"))
+		return
+	}
+
+	byStatus := make(map[string][]storage.SavedVacancy)
+	for _, v := range saved {
+		byStatus[v.Status] = append(byStatus[v.Status], v)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(l.T("pipeline.title") + "\n")
+	for _, st := range pipeline.Statuses {
+		items := byStatus[string(st)]
+		fmt.Fprintf(&sb, "\n%s — %d\n", st.Title(l), len(items))
+		for _, v := range items {
+			fmt.Fprintf(&sb, "  %s — %s", v.Id, v.Name)
+			if v.Employer != "" {
+				fmt.Fprintf(&sb, " (%s)", v.Employer)
+			}
+			sb.WriteString("\n")
+		}
+	}
+	sb.WriteString("\n" + l.T("pipeline.footer"))
+	b.SendMessage(chatID, sb.String())
+}
+
+// addVacancyNote добавляет заметку к сохранённой вакансии
+func (b *Bot) addVacancyNote(chatID int64, vacancyID, text string) error {
+	saved, err := b.Storage.GetSavedVacancy(chatID, vacancyID)
+	if err != nil {
+		return err
+	}
+	saved.Notes = append(saved.Notes, storage.Note{At: time.Now(), Text: text})
+	if err := b.Storage.SaveVacancy(chatID, *saved); err != nil {
+		return err
+	}
+	b.recordAction(chatID, vacancyID, actionNote)
+	return nil
+}
+
+// editKeyboard заменяет клавиатуру под сообщением
+func (b *Bot) editKeyboard(msg *tgbotapi.Message, markup tgbotapi.InlineKeyboardMarkup) {
+	edit := tgbotapi.NewEditMessageReplyMarkup(msg.Chat.ID, msg.MessageID, markup)
+	_, _ = b.Api.Request(edit)
+}
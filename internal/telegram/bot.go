@@ -1,148 +1,694 @@
 package telegram
 
 import (
-	"log"
+	"fmt"
+	"log/slog"
+	"os"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"hhruBot/internal/config"
 	"hhruBot/internal/hh"
+	"hhruBot/internal/i18n"
+	"hhruBot/internal/logging"
+	"hhruBot/internal/metrics"
+	"hhruBot/internal/notify"
+	"hhruBot/internal/salary"
+	"hhruBot/internal/scoring"
+	"hhruBot/internal/sources"
 	"hhruBot/internal/storage"
+	"hhruBot/internal/tracking"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
 
 type Bot struct {
-	Api       *tgbotapi.BotAPI
-	Storage   *storage.Storage
-	HHClient  *hh.Client
-	StopChans map[int64]chan struct{}
+	Api               *tgbotapi.BotAPI
+	Storage           *storage.Storage
+	HHClient          *hh.Client
+	StopChans         map[int64]chan struct{}
+	EmployerStopChans map[int64]chan struct{}
+	Admins            map[int64]bool
+	Settings          config.Search
+	Sources           sources.Set
+	Notifiers         notify.Set
+	PublicURL         string
+
+	// Чекеры запускаются и останавливаются из Telegram и из HTTP API,
+	// поэтому StopChans и EmployerStopChans меняем только под мьютексом
+	mu sync.Mutex
 }
 
-func NewBot(cfg *config.Config, storage *storage.Storage) *Bot {
+func NewBot(cfg *config.Config, storage *storage.Storage, hhClient *hh.Client) *Bot {
 	api, err := tgbotapi.NewBotAPI(cfg.TelegramBotToken)
 	if err != nil {
-		log.Fatal("Не удалось создать бота")
+		slog.Error("не удалось создать бота", logging.Err(err))
+		os.Exit(1)
 	}
 
-	log.Printf("Авторизация прошла как: %s", api.Self.UserName)
+	slog.Info("авторизация прошла", "username", api.Self.UserName)
 
 	b := &Bot{
-		Api:       api,
-		Storage:   storage,
-		HHClient:  hh.NewClient(),
-		StopChans: make(map[int64]chan struct{}),
+		Api:               api,
+		Storage:           storage,
+		HHClient:          hhClient,
+		StopChans:         make(map[int64]chan struct{}),
+		EmployerStopChans: make(map[int64]chan struct{}),
+		Admins:            make(map[int64]bool),
+		Settings:          cfg.Search,
+		Sources:           sources.FromConfig(cfg, hhClient),
+		Notifiers:         notify.FromConfig(cfg),
+		PublicURL:         cfg.PublicURL,
+	}
+	b.Notifiers[notify.Telegram] = telegramNotifier{bot: b}
+	for _, id := range cfg.AdminIDs {
+		b.Admins[id] = true
+	}
+
+	// 📈 Почасовая статистика запросов к hh.ru для /stats
+	b.HHClient.Observer = func(status int, duration time.Duration, err error) {
+		metrics.HHRequests.Inc(strconv.Itoa(status))
+		metrics.HHRequestDuration.Observe(duration.Seconds(), strconv.Itoa(status))
+		storage.IncrStat("hh_requests")
+		if err != nil || status >= 400 {
+			storage.IncrStat("hh_errors")
+		}
 	}
 
 	// 🔄 Автоматический запуск чекеров для активных пользователей
 	users, err := storage.GetActiveUsers()
 	if err != nil {
-		log.Printf("⚠ Не удалось получить список активных пользователей: %v", err)
+		slog.Warn("не удалось получить список активных пользователей", logging.Err(err))
 	} else {
 		for _, chatID := range users {
-			log.Printf("▶ Автозапуск чекера для chatID %d", chatID)
-			stopCh := make(chan struct{})
-			b.StopChans[chatID] = stopCh
-			go StartUserVacancyChecker(chatID, b.HHClient, b.Storage, b, stopCh)
+			logging.Chat(chatID).Info("автозапуск чекера")
+			b.startChecker(chatID)
 		}
 	}
 
+	// 🏢 Чекеры работодателей для всех, кто за ними следит и не на паузе
+	if allUsers, err := storage.GetUsers(); err == nil {
+		for _, chatID := range allUsers {
+			if paused, _ := storage.IsUserPaused(chatID); paused {
+				continue
+			}
+			if employers, err := storage.GetWatchedEmployers(chatID); err == nil && len(employers) > 0 {
+				b.startEmployerWatcher(chatID)
+			}
+		}
+	}
+
+	go StartVacancyTracker(b.HHClient, b.Storage, b)
+	go StartTimerLoop(b.Storage, b)
+
 	return b
 }
 
+// startChecker (пере)запускает чекер вакансий пользователя
+func (b *Bot) startChecker(chatID int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if stopCh, ok := b.StopChans[chatID]; ok {
+		close(stopCh)
+	}
+	stopCh := make(chan struct{})
+	b.StopChans[chatID] = stopCh
+	go StartUserVacancyChecker(chatID, b.HHClient, b.Storage, b, stopCh)
+}
+
+func (b *Bot) stopChecker(chatID int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if stopCh, ok := b.StopChans[chatID]; ok {
+		close(stopCh)
+		delete(b.StopChans, chatID)
+	}
+}
+
+func (b *Bot) checkerRunning(chatID int64) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	_, ok := b.StopChans[chatID]
+	return ok
+}
+
+// checkerCounts — число запущенных чекеров вакансий и работодателей
+func (b *Bot) checkerCounts() (int, int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.StopChans), len(b.EmployerStopChans)
+}
+
+// pauseSearch ставит поиск на паузу и останавливает чекеры пользователя
+func (b *Bot) pauseSearch(chatID int64) error {
+	if err := b.Storage.PauseUser(chatID); err != nil {
+		return err
+	}
+	b.stopChecker(chatID)
+	b.stopEmployerWatcher(chatID)
+	return nil
+}
+
+// resumeSearch снимает паузу и запускает чекеры. Возвращает false, если поиск
+// не был на паузе.
+func (b *Bot) resumeSearch(chatID int64) (bool, error) {
+	if paused, _ := b.Storage.IsUserPaused(chatID); !paused {
+		return false, nil
+	}
+	if err := b.Storage.ResumeUser(chatID); err != nil {
+		return false, err
+	}
+	b.startChecker(chatID)
+	if employers, _ := b.Storage.GetWatchedEmployers(chatID); len(employers) > 0 {
+		b.startEmployerWatcher(chatID)
+	}
+	return true, nil
+}
+
+// Ready проверяет соединение с Telegram (для /readyz)
+func (b *Bot) Ready() error {
+	_, err := b.Api.GetMe()
+	return err
+}
+
+// defaultIntervalMin — интервал проверки в минутах, если пользователь его не задал
+func (b *Bot) defaultIntervalMin() int {
+	return int(b.Settings.DefaultInterval / time.Minute)
+}
+
+// minIntervalMin — минимальный интервал проверки в минутах
+func (b *Bot) minIntervalMin() int {
+	return int(b.Settings.MinInterval / time.Minute)
+}
+
 func (b *Bot) SendMessage(chatID int64, text string) {
 	msg := tgbotapi.NewMessage(chatID, text)
-	_, err := b.Api.Send(msg)
+	_, err := b.send(msg)
 	if err != nil {
-		log.Printf("Не удалось отправить сообщение %d: %v", chatID, err)
+		logging.Chat(chatID).Warn("не удалось отправить сообщение", logging.Err(err))
 	}
 }
 
 func (b *Bot) Start() {
 	u := tgbotapi.NewUpdate(0)
 	u.Timeout = 60
+	u.AllowedUpdates = []string{
+		tgbotapi.UpdateTypeMessage,
+		tgbotapi.UpdateTypeChannelPost,
+		tgbotapi.UpdateTypeCallbackQuery,
+		tgbotapi.UpdateTypeInlineQuery,
+	}
 
 	updates := b.Api.GetUpdatesChan(u)
 
 	for update := range updates {
-		if update.Message == nil {
+		if update.InlineQuery != nil {
+			go b.handleInlineQuery(update.InlineQuery)
+			continue
+		}
+		if update.CallbackQuery != nil {
+			b.handleCallback(update.CallbackQuery)
+			continue
+		}
+		message := update.Message
+		if message == nil {
+			message = update.ChannelPost
+		}
+		if message == nil {
 			continue
 		}
 
-		chatID := update.Message.Chat.ID
-		text := update.Message.Text
+		chatID := message.Chat.ID
+		text, ok := b.normalizeCommand(message.Text)
+		if !ok {
+			continue
+		}
+		if message.Chat.IsPrivate() {
+			b.rememberLanguageCode(chatID, message.From)
+		}
+		l := b.lang(chatID)
+
+		// В группах и каналах реагируем только на команды, а менять настройки могут только админы
+		if !message.Chat.IsPrivate() {
+			if !strings.HasPrefix(text, "/") {
+				continue
+			}
+			if !readOnlyCommands[commandName(text)] && !b.canManage(message.Chat, message.From, message.SenderChat) {
+				b.SendMessage(chatID, l.T("group.admins_only"))
+				continue
+			}
+		}
+
+		// Админские команды — только в личке администратора, в остальных чатах
+		// они неизвестны и не должны попасть в обычные команды с тем же началом
+		if adminCommands[commandName(text)] {
+			if !message.Chat.IsPrivate() || !b.isAdmin(chatID) || !b.handleAdminCommand(chatID, text) {
+				b.SendMessage(chatID, l.T("unknown_command"))
+			}
+			continue
+		}
 
 		switch {
-		case strings.HasPrefix(text, "/start"):
+		case strings.HasPrefix(text, "/start") && !message.Chat.IsPrivate():
 			b.Storage.AddUser(chatID)
-			b.SendMessage(chatID, `👋 Добро пожаловать в HH.ru Бот!
+			b.SendMessage(chatID, l.T("start.group", message.Chat.Title))
 
-Я помогу тебе следить за новыми вакансиями.
-
-⚙️ Основные команды:
-/tags golang,devops — задать ключевые слова
-/city Москва — выбрать город(а)
-/interval 30 — интервал проверки (в минутах)
-/pause — приостановить уведомления
-/search — возобновить работу
-/settings — показать текущие настройки
-/help — справка по командам`)
+		case strings.HasPrefix(text, "/start"):
+			b.Storage.AddUser(chatID)
+			b.SendMessage(chatID, l.T("start.private"))
 
 		case strings.HasPrefix(text, "/tags"):
 			tags := strings.TrimSpace(strings.TrimPrefix(text, "/tags"))
 			if tags == "" {
-				b.SendMessage(chatID, "Введите ключевые слова после команды, пример:\n/tags golang,devops")
+				b.SendMessage(chatID, l.T("tags.usage"))
 				continue
 			}
 			err := b.Storage.SetUserSetting(chatID, "tags", tags)
 			if err != nil {
-				b.SendMessage(chatID, "Ошибка при сохранении тегов")
+				b.SendMessage(chatID, l.T("tags.error"))
 			} else {
 				_ = b.Storage.AddUser(chatID)
-				b.SendMessage(chatID, "Теги сохранены: "+tags)
+				b.SendMessage(chatID, l.T("tags.saved", tags))
 			}
 
 		case strings.HasPrefix(text, "/city"):
 			cities := strings.TrimSpace(strings.TrimPrefix(text, "/city"))
 			if cities == "" {
-				b.SendMessage(chatID, "Введите города после команды, пример:\n/city Москва,Санкт-Петербург")
+				b.SendMessage(chatID, l.T("city.usage"))
 				continue
 			}
 			err := b.Storage.SetUserSetting(chatID, "cities", cities)
 			if err != nil {
-				b.SendMessage(chatID, "Ошибка при сохранении городов")
+				b.SendMessage(chatID, l.T("city.error"))
 			} else {
 				_ = b.Storage.AddUser(chatID)
-				b.SendMessage(chatID, "Города сохранены: "+cities)
+				b.SendMessage(chatID, l.T("city.saved", cities))
 			}
 
 		case strings.HasPrefix(text, "/interval"):
 			intervalStr := strings.TrimSpace(strings.TrimPrefix(text, "/interval"))
 			intervalMin, err := strconv.Atoi(intervalStr)
 			if err != nil || intervalMin <= 0 {
-				b.SendMessage(chatID, "Интервал должен быть положительным числом в минутах, пример:\n/interval 30")
+				b.SendMessage(chatID, l.T("interval.usage"))
 				continue
 			}
 
-			if intervalMin < 5 {
-				b.SendMessage(chatID, "⚠ Минимальный интервал — 5 минут.")
+			if intervalMin < b.minIntervalMin() {
+				b.SendMessage(chatID, l.T("interval.min", l.N("minutes", b.minIntervalMin())))
 				continue
 			}
 
 			err = b.Storage.SetUserSetting(chatID, "interval", intervalStr)
 			if err != nil {
-				b.SendMessage(chatID, "Ошибка при сохранении интервала")
+				b.SendMessage(chatID, l.T("interval.error"))
 				return
 			}
 
-			b.SendMessage(chatID, "Интервал сохранён: "+intervalStr+" мин.")
+			b.SendMessage(chatID, l.T("interval.saved", l.N("minutes", intervalMin)))
+			b.startChecker(chatID)
+
+		case strings.HasPrefix(text, "/keywords"):
+			keywords := strings.TrimSpace(strings.TrimPrefix(text, "/keywords"))
+			if keywords == "" {
+				b.SendMessage(chatID, l.T("keywords.usage"))
+				continue
+			}
+			if keywords == "off" {
+				if err := b.Storage.SetUserSetting(chatID, "keywords", ""); err != nil {
+					b.SendMessage(chatID, l.T("keywords.error"))
+					continue
+				}
+				b.SendMessage(chatID, l.T("keywords.cleared"))
+				continue
+			}
+			if _, _, err := scoring.ParseWeighted(keywords, scoring.DefaultKeywordWeight); err != nil {
+				b.SendMessage(chatID, "❌ "+l.Err(err))
+				continue
+			}
+			if err := b.Storage.SetUserSetting(chatID, "keywords", keywords); err != nil {
+				b.SendMessage(chatID, l.T("keywords.error"))
+				continue
+			}
+			b.SendMessage(chatID, l.T("keywords.saved", keywords))
+
+		case strings.HasPrefix(text, "/skills"):
+			skills := strings.TrimSpace(strings.TrimPrefix(text, "/skills"))
+			if skills == "" {
+				b.SendMessage(chatID, l.T("skills.usage"))
+				continue
+			}
+			if skills == "off" {
+				if err := b.Storage.SetUserSetting(chatID, "skills", ""); err != nil {
+					b.SendMessage(chatID, l.T("skills.error"))
+					continue
+				}
+				b.SendMessage(chatID, l.T("skills.cleared"))
+				continue
+			}
+			if _, _, err := scoring.ParseWeighted(skills, scoring.DefaultSkillWeight); err != nil {
+				b.SendMessage(chatID, "❌ "+l.Err(err))
+				continue
+			}
+			if err := b.Storage.SetUserSetting(chatID, "skills", skills); err != nil {
+				b.SendMessage(chatID, l.T("skills.error"))
+				continue
+			}
+			b.SendMessage(chatID, l.T("skills.saved", skills))
+
+		case strings.HasPrefix(text, "/minsalary"):
+			salaryStr := strings.TrimSpace(strings.TrimPrefix(text, "/minsalary"))
+			salary, err := strconv.Atoi(salaryStr)
+			if err != nil || salary < 0 {
+				b.SendMessage(chatID, l.T("minsalary.usage"))
+				continue
+			}
+			if err := b.Storage.SetUserSetting(chatID, "min_salary", salaryStr); err != nil {
+				b.SendMessage(chatID, l.T("minsalary.error"))
+				continue
+			}
+			b.SendMessage(chatID, l.T("minsalary.saved", salaryStr))
+
+		case strings.HasPrefix(text, "/threshold"):
+			thresholdStr := strings.TrimSpace(strings.TrimPrefix(text, "/threshold"))
+			if _, err := strconv.Atoi(thresholdStr); err != nil {
+				b.SendMessage(chatID, l.T("threshold.usage"))
+				continue
+			}
+			if err := b.Storage.SetUserSetting(chatID, "threshold", thresholdStr); err != nil {
+				b.SendMessage(chatID, l.T("threshold.error"))
+				continue
+			}
+			b.SendMessage(chatID, l.T("threshold.saved", thresholdStr))
+
+		case strings.HasPrefix(text, "/currency"):
+			currency := salary.NormalizeCurrency(strings.TrimPrefix(text, "/currency"))
+			if currency == "" {
+				b.SendMessage(chatID, l.T("currency.usage"))
+				continue
+			}
+			if _, ok := loadCurrencyRates(b.HHClient, b.Storage)[currency]; !ok {
+				b.SendMessage(chatID, l.T("currency.unknown", currency))
+				continue
+			}
+			if err := b.Storage.SetUserSetting(chatID, "currency", currency); err != nil {
+				b.SendMessage(chatID, l.T("currency.error"))
+				continue
+			}
+			b.SendMessage(chatID, l.T("currency.saved", currency))
+
+		case strings.HasPrefix(text, "/salary_basis"):
+			basis := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(text, "/salary_basis")))
+			if basis != "net" && basis != "gross" {
+				b.SendMessage(chatID, l.T("salary_basis.usage"))
+				continue
+			}
+			if err := b.Storage.SetUserSetting(chatID, "salary_basis", basis); err != nil {
+				b.SendMessage(chatID, l.T("salary_basis.error"))
+				continue
+			}
+			b.SendMessage(chatID, l.T("salary_basis.saved", basis))
+
+		case strings.HasPrefix(text, "/tracked"):
+			ids, err := b.Storage.GetTrackedIDs(chatID)
+			if err != nil {
+				b.SendMessage(chatID, l.T("tracked.error"))
+				continue
+			}
+			if len(ids) == 0 {
+				b.SendMessage(chatID, l.T("tracked.empty"))
+				continue
+			}
+			var sb strings.Builder
+			sb.WriteString(l.T("tracked.title") + "\n")
+			for _, id := range ids {
+				v, err := b.Storage.GetTrackedVacancy(chatID, id)
+				if err != nil {
+					continue
+				}
+				status := ""
+				if v.Archived {
+					status = l.T("tracked.archived")
+				}
+				sb.WriteString(l.T("tracked.line", v.Id, v.Name, status, tracking.FormatSalary(*v, l)) + "\n")
+			}
+			sb.WriteString("\n" + l.T("tracked.footer"))
+			b.SendMessage(chatID, sb.String())
+
+		case strings.HasPrefix(text, "/untrack"):
+			vacancyID, err := parseVacancyID(strings.TrimPrefix(text, "/untrack"))
+			if err != nil {
+				b.SendMessage(chatID, "❌ "+l.Err(err))
+				continue
+			}
+			if vacancyID == "" {
+				b.SendMessage(chatID, l.T("untrack.usage"))
+				continue
+			}
+			if err := b.Storage.UntrackVacancy(chatID, vacancyID); err != nil {
+				b.SendMessage(chatID, l.T("untrack.error"))
+				continue
+			}
+			b.SendMessage(chatID, l.T("untrack.done", vacancyID))
+
+		case strings.HasPrefix(text, "/track"):
+			vacancyID, err := parseVacancyID(strings.TrimPrefix(text, "/track"))
+			if err != nil {
+				b.SendMessage(chatID, "❌ "+l.Err(err))
+				continue
+			}
+			if vacancyID == "" {
+				b.SendMessage(chatID, l.T("track.usage"))
+				continue
+			}
+			snapshot, err := b.trackVacancy(chatID, vacancyID)
+			if err != nil {
+				b.SendMessage(chatID, l.T("track.error", vacancyID))
+				continue
+			}
+			b.SendMessage(chatID, l.T("track.done", snapshot.Name))
+
+		case strings.HasPrefix(text, "/changes"):
+			vacancyID, err := parseVacancyID(strings.TrimPrefix(text, "/changes"))
+			if err != nil {
+				b.SendMessage(chatID, "❌ "+l.Err(err))
+				continue
+			}
+			if vacancyID == "" {
+				b.SendMessage(chatID, l.T("changes.usage"))
+				continue
+			}
+			changes, err := b.Storage.GetVacancyChanges(chatID, vacancyID)
+			if err != nil {
+				b.SendMessage(chatID, l.T("changes.error"))
+				continue
+			}
+			if len(changes) == 0 {
+				b.SendMessage(chatID, l.T("changes.empty", vacancyID))
+				continue
+			}
+			loc := b.userLocation(chatID)
+			var sb strings.Builder
+			sb.WriteString(l.T("changes.title", vacancyID) + "\n")
+			for _, c := range changes {
+				sb.WriteString(fmt.Sprintf("%s %s: %s\n", c.At.In(loc).Format(l.T("layout.datetime")), tracking.KindTitle(c.Kind, l), c.Details))
+			}
+			b.SendMessage(chatID, sb.String())
+
+		case strings.HasPrefix(text, "/watch_employer"):
+			query := strings.TrimSpace(strings.TrimPrefix(text, "/watch_employer"))
+			if query == "" {
+				b.SendMessage(chatID, l.T("watch_employer.usage"))
+				continue
+			}
+			employers, err := b.HHClient.SearchEmployers(query)
+			if err != nil {
+				logging.Chat(chatID).Error("не удалось найти работодателя", "query", query, logging.KeyHHStatus, hh.StatusOf(err), logging.Err(err))
+				b.SendMessage(chatID, l.T("watch_employer.error"))
+				continue
+			}
+			switch len(employers) {
+			case 0:
+				b.SendMessage(chatID, l.T("watch_employer.not_found", query))
+			case 1:
+				b.watchEmployer(chatID, employers[0])
+			default:
+				msg := tgbotapi.NewMessage(chatID, l.T("watch_employer.choose"))
+				msg.ReplyMarkup = employerChoiceKeyboard(employers, l)
+				_, _ = b.send(msg)
+			}
+
+		case strings.HasPrefix(text, "/unwatch_employer"):
+			employerID := parseEmployerID(strings.TrimPrefix(text, "/unwatch_employer"))
+			if employerID == "" {
+				b.SendMessage(chatID, l.T("unwatch_employer.usage"))
+				continue
+			}
+			if err := b.Storage.UnwatchEmployer(chatID, employerID); err != nil {
+				b.SendMessage(chatID, l.T("unwatch_employer.error"))
+				continue
+			}
+			if employers, _ := b.Storage.GetWatchedEmployers(chatID); len(employers) == 0 {
+				b.stopEmployerWatcher(chatID)
+			}
+			b.SendMessage(chatID, l.T("unwatch_employer.done", employerID))
+
+		case strings.HasPrefix(text, "/employers"):
+			employers, err := b.Storage.GetWatchedEmployers(chatID)
+			if err != nil {
+				b.SendMessage(chatID, l.T("employers.error"))
+				continue
+			}
+			if len(employers) == 0 {
+				b.SendMessage(chatID, l.T("employers.empty"))
+				continue
+			}
+			var sb strings.Builder
+			sb.WriteString(l.T("employers.title") + "\n")
+			for id, name := range employers {
+				sb.WriteString(fmt.Sprintf("%s — %s\n", id, name))
+			}
+			sb.WriteString("\n" + l.T("employers.footer"))
+			b.SendMessage(chatID, sb.String())
+
+		case strings.HasPrefix(text, "/employer_interval"):
+			intervalStr := strings.TrimSpace(strings.TrimPrefix(text, "/employer_interval"))
+			intervalMin, err := strconv.Atoi(intervalStr)
+			if err != nil || intervalMin < b.minIntervalMin() {
+				b.SendMessage(chatID, l.T("employer_interval.usage", b.minIntervalMin()))
+				continue
+			}
+			if err := b.Storage.SetUserSetting(chatID, "employer_interval", intervalStr); err != nil {
+				b.SendMessage(chatID, l.T("interval.error"))
+				continue
+			}
+			b.SendMessage(chatID, l.T("employer_interval.saved", l.N("minutes", intervalMin)))
+			if b.employerWatcherRunning(chatID) {
+				b.startEmployerWatcher(chatID)
+			}
+
+		case strings.HasPrefix(text, "/saved"):
+			b.sendSavedList(chatID)
+
+		case strings.HasPrefix(text, "/save"):
+			vacancyID, err := parseVacancyID(strings.TrimPrefix(text, "/save"))
+			if err != nil {
+				b.SendMessage(chatID, "❌ "+l.Err(err))
+				continue
+			}
+			if vacancyID == "" {
+				b.SendMessage(chatID, l.T("save.usage"))
+				continue
+			}
+			saved, err := b.saveVacancy(chatID, vacancyID)
+			if err != nil {
+				b.SendMessage(chatID, l.T("save.error", vacancyID))
+				continue
+			}
+			msg := tgbotapi.NewMessage(chatID, formatSavedCard(*saved, l, b.userLocation(chatID)))
+			msg.ReplyMarkup = statusKeyboard(saved.Id, saved.Status, l)
+			_, _ = b.send(msg)
+
+		case strings.HasPrefix(text, "/pipeline"):
+			b.sendPipeline(chatID)
+
+		case strings.HasPrefix(text, "/note"):
+			args := strings.TrimSpace(strings.TrimPrefix(text, "/note"))
+			rawID, note, _ := strings.Cut(args, " ")
+			note = strings.TrimSpace(note)
+			vacancyID, err := parseVacancyID(rawID)
+			if err != nil {
+				b.SendMessage(chatID, "❌ "+l.Err(err))
+				continue
+			}
+			if vacancyID == "" || note == "" {
+				b.SendMessage(chatID, l.T("note.usage"))
+				continue
+			}
+			if err := b.addVacancyNote(chatID, vacancyID, note); err != nil {
+				b.SendMessage(chatID, l.T("note.not_found"))
+				continue
+			}
+			b.SendMessage(chatID, l.T("note.added"))
+
+		case strings.HasPrefix(text, "/reminders"):
+			b.sendReminders(chatID)
+
+		case strings.HasPrefix(text, "/remind"):
+			args := strings.TrimSpace(strings.TrimPrefix(text, "/remind"))
+			t, err := b.scheduleReminder(chatID, args)
+			if err != nil {
+				b.SendMessage(chatID, "❌ "+l.Err(err)+"\n"+l.T("remind.example"))
+				continue
+			}
+			b.SendMessage(chatID, l.T("remind.scheduled", t.Due.In(b.userLocation(chatID)).Format(l.T("layout.datetime_at"))))
+
+		case strings.HasPrefix(text, "/unremind"):
+			timerID := strings.TrimSpace(strings.TrimPrefix(text, "/unremind"))
+			if timerID == "" {
+				b.SendMessage(chatID, l.T("unremind.usage"))
+				continue
+			}
+			cancelled, err := b.Storage.CancelTimer(chatID, timerID)
+			if err != nil {
+				b.SendMessage(chatID, l.T("unremind.error"))
+				continue
+			}
+			if !cancelled {
+				b.SendMessage(chatID, l.T("unremind.not_found", timerID))
+				continue
+			}
+			b.SendMessage(chatID, l.T("unremind.done"))
+
+		case strings.HasPrefix(text, "/followup"):
+			daysStr := strings.TrimSpace(strings.TrimPrefix(text, "/followup"))
+			days, err := strconv.Atoi(daysStr)
+			if err != nil || days < 0 {
+				b.SendMessage(chatID, l.T("followup.usage"))
+				continue
+			}
+			if err := b.Storage.SetUserSetting(chatID, "followup_days", daysStr); err != nil {
+				b.SendMessage(chatID, l.T("followup.error"))
+				continue
+			}
+			b.SendMessage(chatID, l.T("followup.saved", l.N("days", days)))
 
-			if stopCh, ok := b.StopChans[chatID]; ok {
-				close(stopCh)
+		case strings.HasPrefix(text, "/tz"):
+			name := strings.TrimSpace(strings.TrimPrefix(text, "/tz"))
+			if _, err := time.LoadLocation(name); err != nil || name == "" {
+				b.SendMessage(chatID, l.T("tz.usage"))
+				continue
+			}
+			if err := b.Storage.SetUserSetting(chatID, "timezone", name); err != nil {
+				b.SendMessage(chatID, l.T("tz.error"))
+				continue
+			}
+			b.SendMessage(chatID, l.T("tz.saved", name))
+
+		case strings.HasPrefix(text, "/lang"):
+			b.sendLang(chatID, strings.TrimPrefix(text, "/lang"))
+
+		case strings.HasPrefix(text, "/find"):
+			b.startFind(chatID, strings.TrimPrefix(text, "/find"))
+
+		case strings.HasPrefix(text, "/thread"):
+			arg := strings.TrimSpace(strings.TrimPrefix(text, "/thread"))
+			if arg == "off" {
+				_ = b.Storage.SetUserSetting(chatID, "thread_id", "0")
+				b.SendMessage(chatID, l.T("thread.off"))
+				continue
+			}
+			threadID, err := strconv.Atoi(arg)
+			if err != nil || threadID <= 0 {
+				b.SendMessage(chatID, l.T("thread.usage"))
+				continue
+			}
+			if err := b.Storage.SetUserSetting(chatID, "thread_id", arg); err != nil {
+				b.SendMessage(chatID, l.T("thread.error"))
+				continue
 			}
-			newStopCh := make(chan struct{})
-			b.StopChans[chatID] = newStopCh
-			go StartUserVacancyChecker(chatID, b.HHClient, b.Storage, b, newStopCh)
+			b.SendMessage(chatID, l.T("thread.saved", arg))
 
 		case strings.HasPrefix(text, "/settings"):
 			tags, _ := b.Storage.GetUserSetting(chatID, "tags")
@@ -150,78 +696,180 @@ func (b *Bot) Start() {
 			interval, _ := b.Storage.GetUserSetting(chatID, "interval")
 
 			if tags == "" {
-				tags = "не установлены"
+				tags = l.T("settings.not_set")
 			}
 			if cities == "" {
-				cities = "не установлены"
+				cities = l.T("settings.not_set")
 			}
 
-			if interval == "" {
-				interval = "по умолчанию (30 минут)"
+			keywords, _ := b.Storage.GetUserSetting(chatID, "keywords")
+			skills, _ := b.Storage.GetUserSetting(chatID, "skills")
+			minSalary, _ := b.Storage.GetUserSetting(chatID, "min_salary")
+			threshold, _ := b.Storage.GetUserSetting(chatID, "threshold")
+			if keywords == "" {
+				keywords = l.T("settings.not_set")
+			}
+			if skills == "" {
+				skills = l.T("settings.not_set")
+			}
+			if minSalary == "" {
+				minSalary = l.T("settings.not_used")
+			}
+			if threshold == "" {
+				threshold = "0"
+			}
+
+			pref := loadSalaryConverter(chatID, b.HHClient, b.Storage).Pref
+			basis := l.T("salary.net")
+			if !pref.Net {
+				basis = l.T("salary.gross")
+			}
+
+			if val, err := strconv.Atoi(interval); err == nil && val >= b.minIntervalMin() {
+				interval = l.N("minutes", val)
 			} else {
-				if val, err := strconv.Atoi(interval); err == nil {
-					if val < 5 {
-						interval = "по умолчанию (5 минут)"
-					} else {
-						interval += " минут"
-					}
-				} else {
-					interval = "по умолчанию (30 минут)"
-				}
+				interval = l.T("settings.default_interval", l.N("minutes", b.defaultIntervalMin()))
 			}
 
-			settingsMsg := "📌 *Ваши настройки:*\n" +
-				"🔖 Теги: `" + tags + "`\n" +
-				"🏙️ Города: `" + cities + "`\n" +
-				"⏱️ Интервал: `" + interval + "`"
+			settingsMsg := l.T("settings.text", tags, cities, interval,
+				formatSources(searchSources(mainSearch(chatID, b.Storage)), l),
+				keywords, skills, minSalary, threshold, pref.Currency+", "+basis, i18n.Names[l])
 
 			msg := tgbotapi.NewMessage(chatID, settingsMsg)
 			msg.ParseMode = "Markdown"
-			_, _ = b.Api.Send(msg)
+			_, _ = b.send(msg)
 
 		case strings.HasPrefix(text, "/pause"):
-			err := b.Storage.PauseUser(chatID)
+			if err := b.pauseSearch(chatID); err != nil {
+				b.SendMessage(chatID, l.T("pause.error"))
+				continue
+			}
+			b.SendMessage(chatID, l.T("pause.done"))
+
+		case strings.HasPrefix(text, "/searches"):
+			b.sendSearches(chatID)
+
+		case strings.HasPrefix(text, "/search_add"):
+			search, err := b.addSearch(chatID, strings.TrimPrefix(text, "/search_add"))
 			if err != nil {
-				b.SendMessage(chatID, "❌ Не удалось поставить на паузу.")
+				b.SendMessage(chatID, "❌ "+l.Err(err)+"\n"+l.T("search_add.example"))
 				continue
 			}
-			if stopCh, ok := b.StopChans[chatID]; ok {
-				close(stopCh)
-				delete(b.StopChans, chatID)
+			_ = b.Storage.AddUser(chatID)
+			b.SendMessage(chatID, l.T("search_add.saved", formatSearch(*search, l), search.Id+" "+strings.Join(b.Sources.Names(), ",")))
+
+		case strings.HasPrefix(text, "/search_del"):
+			searchID := strings.TrimSpace(strings.TrimPrefix(text, "/search_del"))
+			if searchID == "" || searchID == mainSearchID {
+				b.SendMessage(chatID, l.T("search_del.usage"))
+				continue
+			}
+			if err := b.Storage.DeleteSavedSearch(chatID, searchID); err != nil {
+				b.SendMessage(chatID, l.T("search_del.not_found", searchID))
+				continue
 			}
-			b.SendMessage(chatID, "⏸️ Поиск вакансий приостановлен. Для продолжения — /search.")
+			_ = b.Storage.DeleteHistory(chatID, searchID)
+			b.SendMessage(chatID, l.T("search_del.done", searchID))
 
-		case strings.HasPrefix(text, "/search"):
-			paused, _ := b.Storage.IsUserPaused(chatID)
-			if !paused {
-				b.SendMessage(chatID, "🔄 Поиск уже активен.")
+		case strings.HasPrefix(text, "/sources"):
+			search, err := b.setSearchSources(chatID, strings.TrimPrefix(text, "/sources"))
+			if err != nil {
+				b.SendMessage(chatID, "❌ "+l.Err(err))
+				continue
+			}
+			b.SendMessage(chatID, l.T("sources.saved",
+				search.Name, formatSources(searchSources(*search), l), strings.Join(b.Sources.Names(), ", "),
+				search.Id, strings.Join(b.Sources.Names(), ",")))
+
+		case strings.HasPrefix(text, "/history"):
+			b.sendHistory(chatID, strings.TrimPrefix(text, "/history"))
+
+		case strings.HasPrefix(text, "/export"):
+			b.sendExport(chatID, strings.TrimPrefix(text, "/export"))
+
+		case strings.HasPrefix(text, "/market"):
+			b.sendMarket(chatID, strings.TrimPrefix(text, "/market"))
+
+		case strings.HasPrefix(text, "/apitoken"):
+			b.sendAPIToken(message, strings.TrimPrefix(text, "/apitoken"))
+
+		case strings.HasPrefix(text, "/feed_reset"):
+			b.sendFeeds(chatID, true)
+
+		case strings.HasPrefix(text, "/feed"):
+			b.sendFeeds(chatID, false)
+
+		case strings.HasPrefix(text, "/channels"):
+			b.sendChannels(chatID)
+
+		case strings.HasPrefix(text, "/channel_add"):
+			ch, err := b.addChannel(chatID, strings.TrimPrefix(text, "/channel_add"))
+			if err != nil {
+				b.SendMessage(chatID, "❌ "+l.Err(err)+"\n"+l.T("channel_add.example"))
+				continue
+			}
+			reply := l.T("channel_add.added", ch.Id, notify.Title(ch.Kind, l), ch.Address)
+			if ch.Secret != "" {
+				reply += "\n" + l.T("channel_add.secret", ch.Secret)
+			}
+			if ch.Pending {
+				reply += "\n\n" + l.T("channel_add.confirm", ch.Address, ch.Id)
+			} else {
+				reply += "\n\n" + l.T("channel_add.route", ch.Id)
+			}
+			b.SendMessage(chatID, reply)
+
+		case strings.HasPrefix(text, "/channel_confirm"):
+			channelID, err := b.confirmChannel(chatID, strings.TrimPrefix(text, "/channel_confirm"))
+			if err != nil {
+				b.SendMessage(chatID, "❌ "+l.Err(err))
+				continue
+			}
+			b.SendMessage(chatID, l.T("channel_confirm.done", channelID)+"\n"+l.T("channel_add.route", channelID))
+
+		case strings.HasPrefix(text, "/channel_del"):
+			channelID := strings.TrimSpace(strings.TrimPrefix(text, "/channel_del"))
+			if channelID == "" || channelID == notify.Telegram {
+				b.SendMessage(chatID, l.T("channel_del.usage"))
+				continue
+			}
+			if err := b.Storage.DeleteChannel(chatID, channelID); err != nil {
+				b.SendMessage(chatID, l.T("channel_del.not_found", channelID))
+				continue
+			}
+			b.SendMessage(chatID, l.T("channel_del.done", channelID))
+
+		case strings.HasPrefix(text, "/route"):
+			scope, err := b.setRoute(chatID, strings.TrimPrefix(text, "/route"))
+			if err != nil {
+				b.SendMessage(chatID, "❌ "+l.Err(err)+"\n"+l.T("route.example"))
 				continue
 			}
+			b.SendMessage(chatID, l.T("route.saved", scope))
 
-			err := b.Storage.ResumeUser(chatID)
+		case strings.HasPrefix(text, "/search"):
+			resumed, err := b.resumeSearch(chatID)
 			if err != nil {
-				b.SendMessage(chatID, "❌ Не удалось возобновить поиск.")
+				b.SendMessage(chatID, l.T("search.error"))
+				continue
+			}
+			if !resumed {
+				b.SendMessage(chatID, l.T("search.already"))
 				continue
 			}
+			b.SendMessage(chatID, l.T("search.resumed"))
 
-			stopCh := make(chan struct{})
-			b.StopChans[chatID] = stopCh
-			go StartUserVacancyChecker(chatID, b.HHClient, b.Storage, b, stopCh)
+		case strings.HasPrefix(text, "/mydata"):
+			b.sendMyData(message)
 
-			b.SendMessage(chatID, "✅ Поиск возобновлён.")
+		case strings.HasPrefix(text, "/deleteme"):
+			b.askDeleteMe(chatID)
 
 		case strings.HasPrefix(text, "/help"):
-			b.SendMessage(chatID, `🛠 Доступные команды:
-/tags — задать ключевые слова
-/city — выбрать города
-/interval — частота поиска (в минутах)
-/pause — остановить рассылку
-/search — возобновить рассылку
-/settings — показать текущие настройки
-/help — показать справку`)
+			b.SendMessage(chatID, l.T("help"))
 
 		default:
-			b.SendMessage(chatID, "Неизвестная команда. Попробуйте /start")
+			b.SendMessage(chatID, l.T("unknown_command"))
 		}
 	}
 }
@@ -0,0 +1,117 @@
+package telegram
+
+import (
+	"log/slog"
+	"strings"
+
+	"hhruBot/internal/i18n"
+	"hhruBot/internal/logging"
+	"hhruBot/internal/pipeline"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// vacancyKeyboard — кнопки под карточкой вакансии
+func vacancyKeyboard(vacancyID string, l i18n.Lang) tgbotapi.InlineKeyboardMarkup {
+	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(l.T("button.track"), "track:"+vacancyID),
+			tgbotapi.NewInlineKeyboardButtonData(l.T("button.save"), "save:"+vacancyID),
+		),
+	)
+}
+
+// handleCallback обрабатывает нажатия на inline-кнопки. Данные кнопки имеют вид "действие:аргумент".
+func (b *Bot) handleCallback(cq *tgbotapi.CallbackQuery) {
+	if cq.Message == nil {
+		b.answerCallback(cq.ID, "")
+		return
+	}
+	chatID := cq.Message.Chat.ID
+	action, arg, _ := strings.Cut(cq.Data, ":")
+	l := b.lang(chatID)
+
+	if !cq.Message.Chat.IsPrivate() && !readOnlyCallbacks[action] && !b.canManage(cq.Message.Chat, cq.From, nil) {
+		b.answerCallback(cq.ID, l.T("callback.admins_only"))
+		return
+	}
+
+	switch action {
+	case "track":
+		snapshot, err := b.trackVacancy(chatID, arg)
+		if err != nil {
+			logging.Chat(chatID).Error("не удалось начать отслеживание", logging.KeyVacancyID, arg, logging.Err(err))
+			b.answerCallback(cq.ID, l.T("callback.track_error"))
+			return
+		}
+		b.answerCallback(cq.ID, l.T("callback.tracking", snapshot.Name))
+
+	case "save":
+		saved, err := b.saveVacancy(chatID, arg)
+		if err != nil {
+			logging.Chat(chatID).Error("не удалось сохранить вакансию", logging.KeyVacancyID, arg, logging.Err(err))
+			b.answerCallback(cq.ID, l.T("callback.save_error"))
+			return
+		}
+		b.answerCallback(cq.ID, pipeline.Saved.Title(l))
+		b.editKeyboard(cq.Message, statusKeyboard(saved.Id, saved.Status, l))
+
+	case "status":
+		vacancyID, statusStr, _ := strings.Cut(arg, ":")
+		status, ok := pipeline.Parse(statusStr)
+		if !ok {
+			b.answerCallback(cq.ID, "")
+			return
+		}
+		saved, err := b.setVacancyStatus(chatID, vacancyID, status)
+		if err != nil {
+			b.answerCallback(cq.ID, l.T("callback.not_saved"))
+			return
+		}
+		b.answerCallback(cq.ID, status.Title(l))
+		b.editKeyboard(cq.Message, statusKeyboard(saved.Id, saved.Status, l))
+
+	case "unsave":
+		if err := b.Storage.DeleteSavedVacancy(chatID, arg); err != nil {
+			b.answerCallback(cq.ID, l.T("callback.unsave_error"))
+			return
+		}
+		b.recordAction(chatID, arg, actionUnsaved)
+		b.answerCallback(cq.ID, l.T("callback.unsaved"))
+		b.editKeyboard(cq.Message, vacancyKeyboard(arg, l))
+
+	case "find":
+		b.handleFindCallback(cq, arg)
+
+	case "broadcast":
+		b.handleBroadcastCallback(cq, arg)
+
+	case "lang":
+		b.handleLangCallback(cq, arg)
+
+	case "deleteme":
+		b.handleDeleteMeCallback(cq, arg)
+
+	case "deleteuser":
+		b.handleDeleteUserCallback(cq, arg)
+
+	case "employer":
+		employer, err := b.HHClient.GetEmployer(arg)
+		if err != nil {
+			logging.Chat(chatID).Error("не удалось загрузить работодателя", "employer_id", arg, logging.Err(err))
+			b.answerCallback(cq.ID, l.T("callback.employer_error"))
+			return
+		}
+		b.answerCallback(cq.ID, "")
+		b.watchEmployer(chatID, *employer)
+
+	default:
+		b.answerCallback(cq.ID, "")
+	}
+}
+
+func (b *Bot) answerCallback(callbackID, text string) {
+	if _, err := b.Api.Request(tgbotapi.NewCallback(callbackID, text)); err != nil {
+		slog.Warn("не удалось ответить на callback", logging.Err(err))
+	}
+}
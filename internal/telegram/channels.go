@@ -0,0 +1,344 @@
+package telegram
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"hhruBot/internal/i18n"
+	"hhruBot/internal/logging"
+	"hhruBot/internal/metrics"
+	"hhruBot/internal/notify"
+	"hhruBot/internal/sources"
+	"hhruBot/internal/storage"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Сколько каналов доставки может добавить пользователь
+const maxChannels = 10
+
+const (
+	// Сколько действует код подтверждения адреса
+	channelCodeTTL = time.Hour
+	// После стольких неверных попыток код сбрасывается и нужен новый
+	channelCodeAttempts = 5
+	// Не чаще одного письма с кодом в минуту на чат
+	channelCodeInterval = time.Minute
+)
+
+// telegramNotifier — доставка в чат, где настроен бот
+type telegramNotifier struct {
+	bot *Bot
+}
+
+func (t telegramNotifier) Send(target notify.Target, items []notify.Item) error {
+	chatID, err := strconv.ParseInt(target.Address, 10, 64)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, item := range items {
+		if _, err := t.bot.send(vacancyCard(chatID, item, target.Lang)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// vacancyCard — карточка вакансии в Telegram с кнопками для вакансий hh.ru
+func vacancyCard(chatID int64, item notify.Item, l i18n.Lang) tgbotapi.MessageConfig {
+	v := item.Vacancy
+	text := l.T("card.title", v.Name, item.URL) + "\n🏙️ " + l.T("card.area", v.Area.Name)
+	if item.Source != "" {
+		text += "\n📰 " + l.T("card.source", item.Source)
+	}
+	if len(item.OtherAreas) > 0 {
+		text += "\n📍 " + l.T("card.other_areas", strings.Join(item.OtherAreas, ", "))
+	}
+	if item.Salary != "" {
+		text += "\n💰 " + l.T("card.salary", item.Salary)
+	}
+	if item.WithScore {
+		text += "\n⭐ " + l.T("card.score", item.Score)
+		if len(item.Reasons) > 0 {
+			text += "\n📝 " + tgbotapi.EscapeText(tgbotapi.ModeMarkdown, strings.Join(item.Reasons, ", "))
+		}
+	}
+	if item.Search != "" {
+		text += "\n🔎 " + l.T("card.search", tgbotapi.EscapeText(tgbotapi.ModeMarkdown, item.Search))
+	}
+
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ParseMode = "Markdown"
+	// Отслеживание и закладки работают через API hh.ru
+	if sources.SourceOf(v) == sources.HH {
+		msg.ReplyMarkup = vacancyKeyboard(v.Id, l)
+	}
+	return msg
+}
+
+// resolveTargets переводит ID каналов в адреса доставки. Чат Telegram —
+// встроенный канал "telegram". Если ни один канал не найден, доставляем в чат.
+// Во все каналы вакансии уходят на языке чата.
+func (b *Bot) resolveTargets(chatID int64, channelIDs []string) []notify.Target {
+	lang := b.lang(chatID)
+	chatTarget := notify.Target{Kind: notify.Telegram, Address: strconv.FormatInt(chatID, 10), Lang: lang}
+	if len(channelIDs) == 0 {
+		channelIDs = b.defaultChannels(chatID)
+	}
+
+	channels, _ := b.Storage.GetChannels(chatID)
+	byID := make(map[string]storage.Channel, len(channels))
+	for _, ch := range channels {
+		byID[ch.Id] = ch
+	}
+
+	var targets []notify.Target
+	for _, id := range channelIDs {
+		if id == notify.Telegram {
+			targets = append(targets, chatTarget)
+			continue
+		}
+		if ch, ok := byID[id]; ok && !ch.Pending {
+			targets = append(targets, notify.Target{Kind: ch.Kind, Address: ch.Address, Secret: ch.Secret, Lang: lang})
+		}
+	}
+	if len(targets) == 0 {
+		targets = append(targets, chatTarget)
+	}
+	return targets
+}
+
+// defaultChannels — каналы пользователя по умолчанию (/route без ID поиска)
+func (b *Bot) defaultChannels(chatID int64) []string {
+	val, _ := b.Storage.GetUserSetting(chatID, "channels")
+	if ids := parseCSV(val); len(ids) > 0 {
+		return ids
+	}
+	return []string{notify.Telegram}
+}
+
+// deliver отправляет вакансии во все каналы и возвращает, какие из них
+// доставлены хотя бы в один канал. Дайджест-каналы (почта) получают все
+// вакансии одним письмом, остальные — по одной.
+func (b *Bot) deliver(chatID int64, channelIDs []string, items []notify.Item) []bool {
+	delivered := make([]bool, len(items))
+	for _, target := range b.resolveTargets(chatID, channelIDs) {
+		n, ok := b.Notifiers[target.Kind]
+		if !ok {
+			logging.Chat(chatID).Warn("канал доставки недоступен", "channel", target.Kind)
+			continue
+		}
+
+		if notify.IsDigest(target.Kind) {
+			if err := n.Send(target, items); err != nil {
+				metrics.NotifyErrors.Inc(target.Kind)
+				logging.Chat(chatID).Error("не удалось отправить дайджест", "channel", target.Kind, logging.Err(err))
+				continue
+			}
+			for i := range delivered {
+				delivered[i] = true
+			}
+			continue
+		}
+
+		for i, item := range items {
+			if err := n.Send(target, []notify.Item{item}); err != nil {
+				metrics.NotifyErrors.Inc(target.Kind)
+				logging.Chat(chatID).Error("не удалось отправить вакансию", "channel", target.Kind, logging.KeyVacancyID, item.Vacancy.Id, logging.Err(err))
+				continue
+			}
+			delivered[i] = true
+		}
+	}
+	return delivered
+}
+
+// addChannel разбирает "/channel_add <вид> <адрес>" и сохраняет канал
+func (b *Bot) addChannel(chatID int64, args string) (*storage.Channel, error) {
+	kind, address, _ := strings.Cut(strings.TrimSpace(args), " ")
+	kind = strings.ToLower(kind)
+	address = strings.TrimSpace(address)
+	if kind == "" || address == "" {
+		return nil, i18n.Errorf("channel_add.kind_and_address")
+	}
+	if kind == notify.Telegram {
+		return nil, i18n.Errorf("channel_add.telegram")
+	}
+	n, ok := b.Notifiers[kind]
+	if !ok {
+		return nil, i18n.Errorf("channel_add.unavailable", kind, strings.Join(b.Notifiers.Names(), ", "))
+	}
+	address, err := notify.ValidateTarget(kind, address)
+	if err != nil {
+		return nil, err
+	}
+
+	channels, err := b.Storage.GetChannels(chatID)
+	if err != nil {
+		return nil, i18n.Errorf("channels.load_failed")
+	}
+	if len(channels) >= maxChannels {
+		return nil, i18n.Errorf("channel_add.too_many", maxChannels)
+	}
+
+	ch := storage.Channel{Id: newChannelID(), Kind: kind, Address: address, AddedAt: time.Now()}
+	if kind == notify.Webhook {
+		ch.Secret = newWebhookSecret()
+	}
+	confirmer, needsCode := n.(notify.Confirmer)
+	if needsCode {
+		if ok, err := b.Storage.AllowChannelCode(chatID, channelCodeInterval); err != nil || !ok {
+			return nil, i18n.Errorf("channel_add.code_throttled")
+		}
+		ch.Pending = true
+	}
+	if err := b.Storage.AddChannel(chatID, ch); err != nil {
+		return nil, i18n.Errorf("channel_add.save_failed")
+	}
+	if !needsCode {
+		return &ch, nil
+	}
+
+	code := newChannelCode()
+	target := notify.Target{Kind: kind, Address: address, Lang: b.lang(chatID)}
+	if err := b.Storage.SetChannelCode(chatID, ch.Id, code, channelCodeTTL); err == nil {
+		err = confirmer.SendCode(target, code)
+	}
+	if err != nil {
+		logging.Chat(chatID).Warn("не удалось отправить код подтверждения канала", "channel", kind, logging.Err(err))
+		_ = b.Storage.DeleteChannel(chatID, ch.Id)
+		return nil, i18n.Errorf("channel_add.code_failed")
+	}
+	return &ch, nil
+}
+
+// confirmChannel разбирает "/channel_confirm <id> <код>"
+func (b *Bot) confirmChannel(chatID int64, args string) (string, error) {
+	channelID, code, _ := strings.Cut(strings.TrimSpace(args), " ")
+	code = strings.TrimSpace(code)
+	if channelID == "" || code == "" {
+		return "", i18n.Errorf("channel_confirm.usage")
+	}
+	ok, err := b.Storage.ConfirmChannel(chatID, channelID, code, channelCodeAttempts)
+	if errors.Is(err, storage.ErrTooManyAttempts) {
+		return "", i18n.Errorf("channel_confirm.attempts", channelID)
+	}
+	if err != nil {
+		return "", i18n.Errorf("channel_confirm.failed")
+	}
+	if !ok {
+		return "", i18n.Errorf("channel_confirm.bad_code")
+	}
+	return channelID, nil
+}
+
+// setRoute разбирает "/route [id поиска] telegram,a1b2c3": без ID поиска меняет
+// каналы пользователя по умолчанию, с ID — каналы конкретного сохранённого поиска.
+// Возвращает, чьи каналы изменены, для ответа пользователю.
+func (b *Bot) setRoute(chatID int64, args string) (string, error) {
+	l := b.lang(chatID)
+	args = strings.TrimSpace(args)
+	var search *storage.SavedSearch
+	if first, rest, found := strings.Cut(args, " "); found {
+		saved, err := b.Storage.GetSavedSearch(chatID, first)
+		if err != nil {
+			return "", i18n.Errorf("search.not_found", first)
+		}
+		search, args = saved, strings.TrimSpace(rest)
+	}
+
+	ids := parseCSV(strings.ToLower(args))
+	if len(ids) == 0 {
+		return "", i18n.Errorf("route.no_channels")
+	}
+	if err := b.validateChannels(chatID, ids); err != nil {
+		return "", err
+	}
+
+	if search == nil {
+		if err := b.Storage.SetUserSetting(chatID, "channels", strings.Join(ids, ",")); err != nil {
+			return "", i18n.Errorf("route.save_failed")
+		}
+		return l.T("route.default"), nil
+	}
+	search.Channels = ids
+	if err := b.Storage.SaveSearch(chatID, *search); err != nil {
+		return "", i18n.Errorf("route.save_failed")
+	}
+	return l.T("route.search", search.Name), nil
+}
+
+// validateChannels проверяет, что каналы есть у пользователя
+func (b *Bot) validateChannels(chatID int64, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	channels, _ := b.Storage.GetChannels(chatID)
+	known := map[string]bool{notify.Telegram: true}
+	for _, ch := range channels {
+		known[ch.Id] = true
+	}
+	for _, id := range ids {
+		if !known[id] {
+			return invalidf("channel.not_found", id)
+		}
+	}
+	return nil
+}
+
+func (b *Bot) sendChannels(chatID int64) {
+	l := b.lang(chatID)
+	channels, err := b.Storage.GetChannels(chatID)
+	if err != nil {
+		b.SendMessage(chatID, l.T("channels.error"))
+		return
+	}
+
+	var sb strings.Builder
+	sb.WriteString(l.T("channels.title") + "\n")
+	sb.WriteString(l.T("channels.this_chat") + "\n")
+	for _, ch := range channels {
+		fmt.Fprintf(&sb, "%s — %s: %s", ch.Id, notify.Title(ch.Kind, l), ch.Address)
+		if ch.Pending {
+			sb.WriteString(" " + l.T("channels.pending", ch.Id))
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("\n" + l.T("channels.default", strings.Join(b.defaultChannels(chatID), ", ")) + "\n")
+	if saved, _ := b.Storage.GetSavedSearches(chatID); len(saved) > 0 {
+		for _, s := range saved {
+			if len(s.Channels) > 0 {
+				sb.WriteString(l.T("channels.search", s.Name, strings.Join(s.Channels, ", ")) + "\n")
+			}
+		}
+	}
+
+	sb.WriteString("\n" + l.T("channels.footer", strings.Join(b.Notifiers.Names(), ", ")))
+	b.SendMessage(chatID, sb.String())
+}
+
+func newChannelID() string {
+	buf := make([]byte, 3)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
+
+// newChannelCode — шестизначный код подтверждения
+func newChannelCode() string {
+	n, _ := rand.Int(rand.Reader, big.NewInt(1_000_000))
+	return fmt.Sprintf("%06d", n.Int64())
+}
+
+func newWebhookSecret() string {
+	buf := make([]byte, 16)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
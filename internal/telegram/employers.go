@@ -0,0 +1,162 @@
+package telegram
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"hhruBot/internal/hh"
+	"hhruBot/internal/i18n"
+	"hhruBot/internal/logging"
+	"hhruBot/internal/metrics"
+	"hhruBot/internal/scoring"
+	"hhruBot/internal/storage"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Интервал проверки работодателей по умолчанию, в минутах
+const defaultEmployerIntervalMin = 60
+
+// StartEmployerWatcher присылает все новые вакансии отслеживаемых работодателей
+// независимо от тегов и городов, со своим интервалом
+func StartEmployerWatcher(
+	chatID int64,
+	hhClient *hh.Client,
+	storage *storage.Storage,
+	bot *Bot,
+	stopCh chan struct{},
+) {
+	metrics.ActiveCheckers.Inc()
+	defer metrics.ActiveCheckers.Dec()
+
+	intervalMin := defaultEmployerIntervalMin
+	if val, err := storage.GetUserSetting(chatID, "employer_interval"); err == nil {
+		if n, err := strconv.Atoi(val); err == nil && n > 0 {
+			intervalMin = n
+		}
+	}
+
+	lastChecked, err := storage.GetEmployersLastChecked(chatID)
+	if err != nil {
+		lastChecked = time.Now().Add(-time.Duration(intervalMin) * time.Minute)
+	}
+
+	ticker := time.NewTicker(time.Duration(intervalMin) * time.Minute)
+	defer ticker.Stop()
+
+	for {
+		now := time.Now()
+		if err := checkEmployerVacancies(chatID, hhClient, storage, bot, lastChecked); errors.Is(err, errMaintenance) {
+			// lastChecked не двигаем до конца обслуживания
+		} else if err != nil {
+			logging.Chat(chatID).Error("ошибка при проверке работодателей", logging.KeyHHStatus, hh.StatusOf(err), logging.Err(err))
+		} else {
+			storage.SetEmployersLastChecked(chatID, now)
+			lastChecked = now
+		}
+
+		select {
+		case <-stopCh:
+			logging.Chat(chatID).Info("остановлен чекер работодателей")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func checkEmployerVacancies(
+	chatID int64,
+	hhClient *hh.Client,
+	storage *storage.Storage,
+	bot *Bot,
+	from time.Time,
+) error {
+	if storage.IsMaintenance() {
+		return errMaintenance
+	}
+
+	employers, err := storage.GetWatchedEmployers(chatID)
+	if err != nil {
+		return err
+	}
+	if len(employers) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(employers))
+	for id := range employers {
+		ids = append(ids, id)
+	}
+
+	vacancies, err := hhClient.GetEmployerVacancies(ids, from)
+	if err != nil {
+		return err
+	}
+	metrics.VacanciesFound.Add(float64(len(vacancies)))
+
+	conv := loadSalaryConverter(chatID, hhClient, storage)
+	var items []scoring.Scored
+	for _, v := range vacancies {
+		sv := scoring.Scored{Vacancy: v}
+		sv.Salary, sv.HasSalary = conv.Convert(v.Salary)
+		items = append(items, sv)
+	}
+
+	pending := collectPending(chatID, employerSeen{storage, chatID}, conv, groupDuplicates(items), false, "")
+	sendVacancies(chatID, storage, bot, nil, pending)
+	return nil
+}
+
+// startEmployerWatcher (пере)запускает чекер работодателей пользователя
+func (b *Bot) startEmployerWatcher(chatID int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if stopCh, ok := b.EmployerStopChans[chatID]; ok {
+		close(stopCh)
+	}
+	stopCh := make(chan struct{})
+	b.EmployerStopChans[chatID] = stopCh
+	go StartEmployerWatcher(chatID, b.HHClient, b.Storage, b, stopCh)
+}
+
+func (b *Bot) stopEmployerWatcher(chatID int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if stopCh, ok := b.EmployerStopChans[chatID]; ok {
+		close(stopCh)
+		delete(b.EmployerStopChans, chatID)
+	}
+}
+
+func (b *Bot) employerWatcherRunning(chatID int64) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	_, ok := b.EmployerStopChans[chatID]
+	return ok
+}
+
+// watchEmployer добавляет работодателя и запускает для него чекер
+func (b *Bot) watchEmployer(chatID int64, e hh.Employer) {
+	l := b.lang(chatID)
+	if err := b.Storage.WatchEmployer(chatID, e.Id, e.Name); err != nil {
+		b.SendMessage(chatID, l.T("watch_employer.save_error"))
+		return
+	}
+	_ = b.Storage.AddUser(chatID)
+	b.startEmployerWatcher(chatID)
+	b.SendMessage(chatID, l.T("watch_employer.done", e.Name, e.Id))
+}
+
+// employerChoiceKeyboard предлагает выбрать одного из найденных работодателей
+func employerChoiceKeyboard(employers []hh.Employer, l i18n.Lang) tgbotapi.InlineKeyboardMarkup {
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for _, e := range employers {
+		label := fmt.Sprintf("%s (%s)", e.Name, l.N("vacancies", e.OpenVacancies))
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(label, "employer:"+e.Id),
+		))
+	}
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
@@ -0,0 +1,172 @@
+package telegram
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"hhruBot/internal/feed"
+	"hhruBot/internal/i18n"
+	"hhruBot/internal/logging"
+	"hhruBot/internal/storage"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Форматы лент в порядке показа в /feed
+var feedFormats = []string{feed.RSS, feed.Atom, feed.JSON}
+
+// FeedHandler отдаёт ленты поисков: /feed/{token}/{id поиска}.{rss|atom|json}
+func (b *Bot) FeedHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		chatID, err := b.Storage.FeedOwner(r.PathValue("token"))
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+
+		file := r.PathValue("file")
+		format := strings.TrimPrefix(path.Ext(file), ".")
+		searchID := strings.TrimSuffix(file, path.Ext(file))
+		contentType, ok := feed.ContentTypes[format]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		var search *storage.SavedSearch
+		for _, s := range loadSearches(chatID, b.Storage) {
+			if s.Id == searchID {
+				search = &s
+				break
+			}
+		}
+		if search == nil {
+			http.NotFound(w, r)
+			return
+		}
+
+		history, err := b.Storage.GetHistory(chatID, searchID)
+		if err != nil {
+			logging.Chat(chatID).Error("не удалось загрузить историю поиска", logging.KeySearchID, searchID, logging.Err(err))
+			http.Error(w, b.lang(chatID).T("api.internal"), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", contentType)
+		f := buildFeed(*search, history, b.feedURL(r.PathValue("token"), searchID, format), b.lang(chatID))
+		if err := f.Write(w, format); err != nil {
+			logging.Chat(chatID).Warn("не удалось отдать ленту", logging.KeySearchID, searchID, logging.Err(err))
+		}
+	})
+}
+
+// buildFeed превращает историю поиска в ленту
+func buildFeed(search storage.SavedSearch, history []storage.HistoryEntry, selfURL string, l i18n.Lang) feed.Feed {
+	f := feed.Feed{
+		Title:       l.T("feed.title", search.Name),
+		Description: l.T("feed.description", strings.Join(search.Tags, ", ")),
+		SelfURL:     selfURL,
+		Updated:     time.Now(),
+	}
+	if len(history) > 0 {
+		f.Updated = history[0].MatchedAt
+	}
+	for _, e := range history {
+		f.Items = append(f.Items, feed.Item{
+			ID:        e.URL,
+			Title:     e.Name,
+			URL:       e.URL,
+			Summary:   historySummary(e, l),
+			Author:    e.Employer,
+			Tags:      search.Tags,
+			Published: e.MatchedAt,
+		})
+	}
+	return f
+}
+
+// historySummary — описание записи ленты без разметки
+func historySummary(e storage.HistoryEntry, l i18n.Lang) string {
+	var lines []string
+	if e.Employer != "" {
+		lines = append(lines, l.T("feed.employer", e.Employer))
+	}
+	if e.Area != "" {
+		lines = append(lines, l.T("feed.city", e.Area))
+	}
+	if e.Salary != "" {
+		lines = append(lines, l.T("feed.salary", e.Salary))
+	}
+	if e.Score != 0 {
+		lines = append(lines, l.T("feed.score", e.Score))
+	}
+	if e.Source != "" {
+		lines = append(lines, l.T("feed.source", e.Source))
+	}
+	if e.Summary != "" {
+		lines = append(lines, e.Summary)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// feedURL — адрес ленты; без внешнего адреса сервера (PUBLIC_URL) только путь
+func (b *Bot) feedURL(token, searchID, format string) string {
+	if b.PublicURL == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/feed/%s/%s.%s", b.PublicURL, token, searchID, format)
+}
+
+// sendFeeds выдаёт токен, если его ещё нет, и присылает адреса лент всех поисков
+func (b *Bot) sendFeeds(chatID int64, reset bool) {
+	l := b.lang(chatID)
+	token, err := b.Storage.GetFeedToken(chatID)
+	if err != nil {
+		b.SendMessage(chatID, l.T("feeds.load_error"))
+		return
+	}
+	if token == "" || reset {
+		token = newFeedToken()
+		if err := b.Storage.SetFeedToken(chatID, token); err != nil {
+			b.SendMessage(chatID, l.T("feeds.save_error"))
+			return
+		}
+	}
+
+	var sb strings.Builder
+	if reset {
+		sb.WriteString(l.T("feeds.reset") + "\n\n")
+	}
+	sb.WriteString(l.T("feeds.title") + "\n")
+	for _, s := range loadSearches(chatID, b.Storage) {
+		fmt.Fprintf(&sb, "\n%s (%s)\n", s.Name, s.Id)
+		for _, format := range feedFormats {
+			link := b.feedURL(token, s.Id, format)
+			if link == "" {
+				link = fmt.Sprintf("/feed/%s/%s.%s", token, s.Id, format)
+			}
+			sb.WriteString(link + "\n")
+		}
+	}
+	if b.PublicURL == "" {
+		sb.WriteString("\n" + l.T("feeds.no_public_url"))
+	}
+	sb.WriteString("\n" + l.T("feeds.footer"))
+
+	msg := tgbotapi.NewMessage(chatID, sb.String())
+	msg.DisableWebPagePreview = true
+	if _, err := b.send(msg); err != nil {
+		logging.Chat(chatID).Warn("не удалось отправить ссылки на ленты", logging.Err(err))
+	}
+}
+
+func newFeedToken() string {
+	buf := make([]byte, 16)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
@@ -0,0 +1,188 @@
+package telegram
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"hhruBot/internal/hh"
+	"hhruBot/internal/i18n"
+	"hhruBot/internal/logging"
+	"hhruBot/internal/storage"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Сколько вакансий показываем на одной странице /find
+const findPerPage = 5
+
+// Короткие имена сортировок для callback data (лимит Telegram — 64 байта).
+// Подписи кнопок — в каталоге сообщений под ключами find.sort.<Key>.
+var findSorts = []struct {
+	Key     string
+	OrderBy string
+}{
+	{"d", hh.OrderByDate},
+	{"s", hh.OrderBySalary},
+	{"r", hh.OrderByRelevance},
+}
+
+// parseFindArgs разбирает "/find <запрос> [город]". Город — часть после последней
+// запятой или последнее слово, если оно известно справочнику городов hh.ru.
+func parseFindArgs(args string) storage.FindState {
+	args = strings.TrimSpace(args)
+
+	if idx := strings.LastIndex(args, ","); idx >= 0 {
+		city := strings.TrimSpace(args[idx+1:])
+		if area := hh.CityToAreaID(city); area != "" {
+			return storage.FindState{Query: strings.TrimSpace(args[:idx]), Area: area, City: city}
+		}
+	}
+
+	fields := strings.Fields(args)
+	if len(fields) > 1 {
+		city := fields[len(fields)-1]
+		if area := hh.CityToAreaID(city); area != "" {
+			return storage.FindState{Query: strings.Join(fields[:len(fields)-1], " "), Area: area, City: city}
+		}
+	}
+
+	return storage.FindState{Query: args}
+}
+
+// startFind отправляет первую страницу результатов и сохраняет запрос
+// под ID отправленного сообщения: по нему листают его кнопки
+func (b *Bot) startFind(chatID int64, args string) {
+	l := b.lang(chatID)
+	state := parseFindArgs(args)
+	if state.Query == "" {
+		b.SendMessage(chatID, l.T("find.usage"))
+		return
+	}
+
+	text, markup, err := b.renderFindPage(chatID, state, 0, findSorts[0].Key)
+	if err != nil {
+		logging.Chat(chatID).Error("ошибка поиска /find", "query", state.Query, logging.KeyHHStatus, hh.StatusOf(err), logging.Err(err))
+		b.SendMessage(chatID, l.T("find.error"))
+		return
+	}
+
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.DisableWebPagePreview = true
+	if markup == nil {
+		_, _ = b.send(msg)
+		return
+	}
+	msg.ReplyMarkup = *markup
+	sent, err := b.send(msg)
+	if err != nil {
+		return
+	}
+	if err := b.Storage.SetFindState(chatID, sent.MessageID, state); err != nil {
+		logging.Chat(chatID).Error("не удалось сохранить запрос /find", logging.Err(err))
+		b.SendMessage(chatID, l.T("find.save_error"))
+	}
+}
+
+// handleFindCallback листает результаты: данные кнопки имеют вид "страница:сортировка"
+func (b *Bot) handleFindCallback(cq *tgbotapi.CallbackQuery, arg string) {
+	chatID := cq.Message.Chat.ID
+	l := b.lang(chatID)
+	pageStr, sortKey, _ := strings.Cut(arg, ":")
+	page, err := strconv.Atoi(pageStr)
+	if err != nil || page < 0 {
+		b.answerCallback(cq.ID, "")
+		return
+	}
+
+	state, err := b.Storage.GetFindState(chatID, cq.Message.MessageID)
+	if err != nil {
+		b.answerCallback(cq.ID, l.T("find.expired"))
+		return
+	}
+
+	text, markup, err := b.renderFindPage(chatID, *state, page, sortKey)
+	if err != nil {
+		logging.Chat(chatID).Error("ошибка поиска /find", "query", state.Query, logging.KeyHHStatus, hh.StatusOf(err), logging.Err(err))
+		b.answerCallback(cq.ID, l.T("find.unavailable"))
+		return
+	}
+	b.answerCallback(cq.ID, "")
+
+	edit := tgbotapi.NewEditMessageText(chatID, cq.Message.MessageID, text)
+	edit.DisableWebPagePreview = true
+	edit.ReplyMarkup = markup
+	_, _ = b.Api.Request(edit)
+}
+
+// renderFindPage выполняет поиск и собирает текст страницы с кнопками навигации.
+// Поиск не учитывает уже просмотренные вакансии и дату последней проверки.
+func (b *Bot) renderFindPage(chatID int64, state storage.FindState, page int, sortKey string) (string, *tgbotapi.InlineKeyboardMarkup, error) {
+	orderBy := findSorts[0].OrderBy
+	for _, s := range findSorts {
+		if s.Key == sortKey {
+			orderBy = s.OrderBy
+		}
+	}
+
+	q := hh.SearchQuery{Text: state.Query, OrderBy: orderBy, Page: page, PerPage: findPerPage}
+	if state.Area != "" {
+		q.Areas = []string{state.Area}
+	}
+	res, err := b.HHClient.Search(q)
+	if err != nil {
+		return "", nil, err
+	}
+
+	title := "🔎 " + state.Query
+	if state.City != "" {
+		title += ", " + state.City
+	}
+	if res.Found == 0 {
+		return title + "\n\n" + b.lang(chatID).T("find.nothing"), nil, nil
+	}
+
+	conv := loadSalaryConverter(chatID, b.HHClient, b.Storage)
+	l := conv.Pref.Lang
+	var sb strings.Builder
+	sb.WriteString(l.T("find.found", title, l.N("vacancies", res.Found)) + "\n")
+	for i, v := range res.Items {
+		fmt.Fprintf(&sb, "\n%d. %s\n", page*findPerPage+i+1, v.Name)
+		if v.Employer.Name != "" {
+			fmt.Fprintf(&sb, "🏢 %s, %s\n", v.Employer.Name, v.Area.Name)
+		}
+		if r, ok := conv.Convert(v.Salary); ok {
+			fmt.Fprintf(&sb, "💰 %s\n", conv.Format(r))
+		}
+		fmt.Fprintf(&sb, "https://hh.ru/vacancy/%s\n", v.Id)
+	}
+
+	markup := findKeyboard(page, res.Pages, sortKey, l)
+	return sb.String(), &markup, nil
+}
+
+func findKeyboard(page, pages int, sortKey string, l i18n.Lang) tgbotapi.InlineKeyboardMarkup {
+	if sortKey == "" {
+		sortKey = findSorts[0].Key
+	}
+
+	var nav []tgbotapi.InlineKeyboardButton
+	if page > 0 {
+		nav = append(nav, tgbotapi.NewInlineKeyboardButtonData("◀️", fmt.Sprintf("find:%d:%s", page-1, sortKey)))
+	}
+	nav = append(nav, tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf("%d / %d", page+1, pages), "noop:"))
+	if page+1 < pages {
+		nav = append(nav, tgbotapi.NewInlineKeyboardButtonData("▶️", fmt.Sprintf("find:%d:%s", page+1, sortKey)))
+	}
+
+	var sorts []tgbotapi.InlineKeyboardButton
+	for _, s := range findSorts {
+		label := l.T("find.sort." + s.Key)
+		if s.Key == sortKey {
+			label = "✅ " + label
+		}
+		sorts = append(sorts, tgbotapi.NewInlineKeyboardButtonData(label, "find:0:"+s.Key))
+	}
+
+	return tgbotapi.NewInlineKeyboardMarkup(nav, sorts)
+}
@@ -0,0 +1,151 @@
+package telegram
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+
+	"hhruBot/internal/logging"
+	"hhruBot/internal/metrics"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// От имени этого бота Telegram присылает сообщения анонимных администраторов группы
+const groupAnonymousBotID = 1087968824
+
+// Команды, которые в группах доступны всем участникам: они ничего не меняют
+var readOnlyCommands = map[string]bool{
+	"/help":      true,
+	"/settings":  true,
+	"/searches":  true,
+	"/market":    true,
+	"/history":   true,
+	"/find":      true,
+	"/tracked":   true,
+	"/changes":   true,
+	"/employers": true,
+	"/saved":     true,
+	"/pipeline":  true,
+	"/reminders": true,
+}
+
+// Кнопки, нажимать которые в группах могут все участники
+var readOnlyCallbacks = map[string]bool{
+	"find": true,
+	"noop": true,
+}
+
+// normalizeCommand убирает из команды суффикс "@BotName". Возвращает false,
+// если команда адресована другому боту.
+func (b *Bot) normalizeCommand(text string) (string, bool) {
+	if !strings.HasPrefix(text, "/") {
+		return text, true
+	}
+
+	command, rest, _ := strings.Cut(text, " ")
+	name, target, addressed := strings.Cut(command, "@")
+	if addressed && !strings.EqualFold(target, b.Api.Self.UserName) {
+		return "", false
+	}
+	if rest != "" {
+		return name + " " + rest, true
+	}
+	return name, true
+}
+
+// privateRecipient — куда отправлять секреты (токены, архив данных): в личке —
+// в сам чат, в группе — в личку автору команды. В канале автор неизвестен.
+func privateRecipient(message *tgbotapi.Message) (int64, bool) {
+	if message.Chat.IsPrivate() {
+		return message.Chat.ID, true
+	}
+	if message.From == nil || message.SenderChat != nil {
+		return 0, false
+	}
+	return message.From.ID, true
+}
+
+// commandName возвращает команду без аргументов: "/tags golang" → "/tags"
+func commandName(text string) string {
+	name, _, _ := strings.Cut(text, " ")
+	return name
+}
+
+// canManage проверяет, может ли автор сообщения менять настройки чата.
+// В личных чатах и каналах (писать туда могут только админы) — всегда да,
+// в группах — только администраторы, проверенные через getChatMember.
+func (b *Bot) canManage(chat *tgbotapi.Chat, from *tgbotapi.User, senderChat *tgbotapi.Chat) bool {
+	if chat.IsPrivate() || chat.IsChannel() {
+		return true
+	}
+	if senderChat != nil && senderChat.ID == chat.ID {
+		// анонимный администратор пишет от имени самой группы
+		return true
+	}
+	if from == nil || from.ID == groupAnonymousBotID {
+		return false
+	}
+
+	member, err := b.Api.GetChatMember(tgbotapi.GetChatMemberConfig{
+		ChatConfigWithUser: tgbotapi.ChatConfigWithUser{ChatID: chat.ID, UserID: from.ID},
+	})
+	if err != nil {
+		logging.Chat(chat.ID).Warn("не удалось проверить права", "user_id", from.ID, logging.Err(err))
+		return false
+	}
+	return member.IsCreator() || member.IsAdministrator()
+}
+
+// threadID возвращает тему форума, в которую бот пишет в этом чате (0 — основной чат)
+func (b *Bot) threadID(chatID int64) int {
+	val, err := b.Storage.GetUserSetting(chatID, "thread_id")
+	if err != nil {
+		return 0
+	}
+	id, _ := strconv.Atoi(val)
+	return id
+}
+
+// send отправляет сообщение с учётом выбранной темы форума. Версия tgbotapi не
+// знает про message_thread_id, поэтому для тем собираем запрос вручную.
+func (b *Bot) send(msg tgbotapi.MessageConfig) (tgbotapi.Message, error) {
+	sent, err := b.sendToThread(msg)
+	if err != nil {
+		code := "network"
+		var tgErr *tgbotapi.Error
+		if errors.As(err, &tgErr) {
+			code = strconv.Itoa(tgErr.Code)
+		}
+		metrics.TelegramSendErrors.Inc(code)
+	}
+	return sent, err
+}
+
+func (b *Bot) sendToThread(msg tgbotapi.MessageConfig) (tgbotapi.Message, error) {
+	thread := b.threadID(msg.ChatID)
+	if thread == 0 {
+		return b.Api.Send(msg)
+	}
+
+	params := tgbotapi.Params{}
+	params.AddFirstValid("chat_id", msg.ChatID)
+	params["text"] = msg.Text
+	params["message_thread_id"] = strconv.Itoa(thread)
+	params.AddNonEmpty("parse_mode", msg.ParseMode)
+	params.AddBool("disable_web_page_preview", msg.DisableWebPagePreview)
+	if msg.ReplyMarkup != nil {
+		if err := params.AddInterface("reply_markup", msg.ReplyMarkup); err != nil {
+			return tgbotapi.Message{}, err
+		}
+	}
+
+	resp, err := b.Api.MakeRequest("sendMessage", params)
+	if err != nil {
+		return tgbotapi.Message{}, err
+	}
+	var sent tgbotapi.Message
+	err = json.Unmarshal(resp.Result, &sent)
+	return sent, err
+}
@@ -0,0 +1,202 @@
+package telegram
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"hhruBot/internal/export"
+	"hhruBot/internal/i18n"
+	"hhruBot/internal/logging"
+	"hhruBot/internal/notify"
+	"hhruBot/internal/pipeline"
+	"hhruBot/internal/storage"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Сколько вакансий показываем по команде /history
+const historyListLimit = 20
+
+// Действия пользователя с отправленной вакансией; смена статуса — "status:<статус>"
+const (
+	actionTracked = "tracked"
+	actionSaved   = "saved"
+	actionUnsaved = "unsaved"
+	actionNote    = "note"
+	actionStatus  = "status:"
+)
+
+// recordSent сохраняет снимок доставленной вакансии в историю чата и, для
+// вакансий поиска, в ленту поиска
+func (b *Bot) recordSent(chatID int64, search *storage.SavedSearch, item notify.Item) {
+	v := item.Vacancy
+	entry := storage.HistoryEntry{
+		Id:         v.Id,
+		Name:       v.Name,
+		Employer:   v.Employer.Name,
+		Area:       v.Area.Name,
+		Salary:     item.Salary,
+		URL:        item.URL,
+		Source:     item.Source,
+		Summary:    v.SnippetText(),
+		SearchName: b.lang(chatID).T("history.employers"),
+		MatchedAt:  time.Now(),
+	}
+	if item.WithScore {
+		entry.Score = item.Score
+	}
+	if search != nil {
+		entry.SearchID, entry.SearchName = search.Id, search.Name
+	}
+
+	if err := b.Storage.AddSent(chatID, entry, b.Settings.HistoryRetention); err != nil {
+		logging.Chat(chatID).Warn("не удалось сохранить историю отправленных вакансий", logging.KeyVacancyID, v.Id, logging.Err(err))
+	}
+	if search == nil {
+		return
+	}
+	if err := b.Storage.AddHistory(chatID, search.Id, entry, b.Settings.HistorySize); err != nil {
+		logging.Chat(chatID).Warn("не удалось сохранить историю поиска", logging.KeySearchID, search.Id, logging.Err(err))
+	}
+}
+
+// recordAction отмечает в истории, что пользователь сделал с вакансией
+func (b *Bot) recordAction(chatID int64, vacancyID, action string) {
+	err := b.Storage.AddSentAction(chatID, vacancyID, storage.HistoryAction{At: time.Now(), Action: action})
+	if err != nil {
+		logging.Chat(chatID).Warn("не удалось записать действие в историю", logging.KeyVacancyID, vacancyID, logging.Err(err))
+	}
+}
+
+func actionTitle(action string, l i18n.Lang) string {
+	switch action {
+	case actionTracked, actionSaved, actionUnsaved, actionNote:
+		return l.T("history.action." + action)
+	}
+	if status, ok := strings.CutPrefix(action, actionStatus); ok {
+		return pipeline.Status(status).Title(l)
+	}
+	return action
+}
+
+// sendHistory показывает последние отправленные вакансии, "/history [запрос]"
+// ищет по названию, компании, городу и поиску
+func (b *Bot) sendHistory(chatID int64, query string) {
+	l := b.lang(chatID)
+	entries, err := b.Storage.GetSent(chatID)
+	if err != nil {
+		b.SendMessage(chatID, l.T("history.load_error"))
+		return
+	}
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query != "" {
+		var found []storage.HistoryEntry
+		for _, e := range entries {
+			if strings.Contains(strings.ToLower(e.Name+" "+e.Employer+" "+e.Area+" "+e.SearchName), query) {
+				found = append(found, e)
+			}
+		}
+		entries = found
+	}
+	if len(entries) == 0 {
+		b.SendMessage(chatID, l.T("history.empty", l.N("days", int(b.Settings.HistoryRetention.Hours()/24))))
+		return
+	}
+
+	loc := b.userLocation(chatID)
+	var sb strings.Builder
+	sb.WriteString(l.T("history.title", len(entries)) + "\n")
+	for _, e := range entries[:min(len(entries), historyListLimit)] {
+		fmt.Fprintf(&sb, "\n%s — %s", e.MatchedAt.In(loc).Format(l.T("layout.short_datetime")), e.Name)
+		if e.Employer != "" {
+			fmt.Fprintf(&sb, " (%s)", e.Employer)
+		}
+		fmt.Fprintf(&sb, "\n🔎 %s · %s", e.SearchName, e.URL)
+		if n := len(e.Actions); n > 0 {
+			sb.WriteString("\n" + actionTitle(e.Actions[n-1].Action, l))
+		}
+		sb.WriteString("\n")
+	}
+	if len(entries) > historyListLimit {
+		sb.WriteString("\n" + l.T("history.truncated", historyListLimit))
+	}
+
+	msg := tgbotapi.NewMessage(chatID, sb.String())
+	msg.DisableWebPagePreview = true
+	if _, err := b.send(msg); err != nil {
+		logging.Chat(chatID).Warn("не удалось отправить историю", logging.Err(err))
+	}
+}
+
+// sendExport выгружает историю отправленных вакансий файлом: /export csv|json|xlsx
+func (b *Bot) sendExport(chatID int64, format string) {
+	l := b.lang(chatID)
+	format = strings.ToLower(strings.TrimSpace(format))
+	if format == "" {
+		format = export.CSV
+	}
+	entries, err := b.Storage.GetSent(chatID)
+	if err != nil {
+		b.SendMessage(chatID, l.T("history.load_error"))
+		return
+	}
+	if len(entries) == 0 {
+		b.SendMessage(chatID, l.T("export.empty"))
+		return
+	}
+
+	var buf bytes.Buffer
+	switch format {
+	case export.CSV:
+		err = historyTable(entries, l, b.userLocation(chatID)).WriteCSV(&buf)
+	case export.XLSX:
+		err = historyTable(entries, l, b.userLocation(chatID)).WriteXLSX(&buf, l.T("export.sheet"))
+	case export.JSON:
+		enc := json.NewEncoder(&buf)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(entries)
+	default:
+		b.SendMessage(chatID, l.T("export.usage", strings.Join(export.Formats, "|")))
+		return
+	}
+	if err != nil {
+		logging.Chat(chatID).Error("не удалось выгрузить историю", "format", format, logging.Err(err))
+		b.SendMessage(chatID, l.T("export.error"))
+		return
+	}
+
+	name := fmt.Sprintf("vacancies-%s.%s", time.Now().Format("2006-01-02"), format)
+	doc := tgbotapi.NewDocument(chatID, tgbotapi.FileBytes{Name: name, Bytes: buf.Bytes()})
+	doc.Caption = l.T("history.title", len(entries))
+	if _, err := b.Api.Send(doc); err != nil {
+		logging.Chat(chatID).Warn("не удалось отправить выгрузку", logging.Err(err))
+	}
+}
+
+// historyTable — история в виде таблицы для CSV и XLSX, время — в поясе loc
+func historyTable(entries []storage.HistoryEntry, l i18n.Lang, loc *time.Location) export.Table {
+	var t export.Table
+	for _, col := range []string{"sent", "vacancy", "employer", "city", "salary", "score", "source", "search", "url", "actions"} {
+		t.Header = append(t.Header, l.T("export.column."+col))
+	}
+	layout := l.T("layout.datetime")
+	for _, e := range entries {
+		var actions []string
+		for _, a := range e.Actions {
+			actions = append(actions, a.At.In(loc).Format(layout)+" "+actionTitle(a.Action, l))
+		}
+		score := ""
+		if e.Score != 0 {
+			score = strconv.Itoa(e.Score)
+		}
+		t.Rows = append(t.Rows, []string{
+			e.MatchedAt.In(loc).Format(layout), e.Name, e.Employer, e.Area, e.Salary,
+			score, e.Source, e.SearchName, e.URL, strings.Join(actions, "; "),
+		})
+	}
+	return t
+}
@@ -0,0 +1,108 @@
+package telegram
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"hhruBot/internal/hh"
+	"hhruBot/internal/logging"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const (
+	inlineResultsLimit = 20
+	inlineCacheTTL     = 5 * time.Minute
+	inlineThrottle     = time.Second
+)
+
+// handleInlineQuery отвечает на "@HHRUGoBot golang москва" карточками вакансий
+func (b *Bot) handleInlineQuery(q *tgbotapi.InlineQuery) {
+	query := strings.TrimSpace(q.Query)
+	if query == "" {
+		return
+	}
+
+	vacancies, err := b.inlineSearch(q.From.ID, query)
+	if err != nil {
+		logging.Chat(q.From.ID).Error("ошибка inline-поиска", "query", query, logging.KeyHHStatus, hh.StatusOf(err), logging.Err(err))
+		return
+	}
+	if vacancies == nil {
+		// пользователь печатает слишком быстро — ответим на следующий запрос
+		return
+	}
+
+	conv := loadSalaryConverter(q.From.ID, b.HHClient, b.Storage)
+	results := make([]interface{}, 0, len(vacancies))
+	for _, v := range vacancies {
+		url := "https://hh.ru/vacancy/" + v.Id
+
+		details := []string{v.Employer.Name, v.Area.Name}
+		text := fmt.Sprintf("%s\n🏢 %s, %s", v.Name, v.Employer.Name, v.Area.Name)
+		if r, ok := conv.Convert(v.Salary); ok {
+			details = append([]string{conv.Format(r)}, details...)
+			text += "\n💰 " + conv.Format(r)
+		}
+		text += "\n" + url
+
+		article := tgbotapi.NewInlineQueryResultArticle(v.Id, v.Name, text)
+		article.Description = strings.Join(details, " · ")
+		article.URL = url
+		results = append(results, article)
+	}
+
+	answer := tgbotapi.InlineConfig{
+		InlineQueryID: q.ID,
+		Results:       results,
+		CacheTime:     int(inlineCacheTTL.Seconds()),
+		IsPersonal:    true,
+	}
+	if _, err := b.Api.Request(answer); err != nil {
+		logging.Chat(q.From.ID).Warn("не удалось ответить на inline-запрос", logging.Err(err))
+	}
+}
+
+// inlineSearch берёт результаты из кэша или выполняет поиск на hh.ru.
+// Возвращает nil без ошибки, если запрос отброшен ограничением частоты.
+func (b *Bot) inlineSearch(userID int64, query string) ([]hh.Vacancy, error) {
+	sum := sha1.Sum([]byte(strings.ToLower(query)))
+	cacheKey := hex.EncodeToString(sum[:])
+
+	if data, err := b.Storage.GetInlineCache(cacheKey); err == nil {
+		var cached []hh.Vacancy
+		if err := json.Unmarshal(data, &cached); err == nil {
+			return cached, nil
+		}
+	}
+
+	if !b.Storage.AllowInlineQuery(userID, inlineThrottle) {
+		return nil, nil
+	}
+
+	state := parseFindArgs(query)
+	q := hh.SearchQuery{Text: state.Query, OrderBy: hh.OrderByRelevance, PerPage: inlineResultsLimit}
+	if state.Area != "" {
+		q.Areas = []string{state.Area}
+	}
+	res, err := b.HHClient.Search(q)
+	if err != nil {
+		return nil, err
+	}
+
+	vacancies := res.Items
+	if vacancies == nil {
+		vacancies = []hh.Vacancy{}
+	}
+	if data, err := json.Marshal(vacancies); err == nil {
+		if err := b.Storage.SetInlineCache(cacheKey, data, inlineCacheTTL); err != nil {
+			slog.Warn("не удалось сохранить inline-кэш", logging.Err(err))
+		}
+	}
+	return vacancies, nil
+}
@@ -0,0 +1,99 @@
+package telegram
+
+import (
+	"strings"
+
+	"hhruBot/internal/i18n"
+	"hhruBot/internal/logging"
+	"hhruBot/internal/storage"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// userLang — язык сообщений чата: выбранный командой /lang, иначе из профиля
+// Telegram автора последнего сообщения, иначе язык по умолчанию
+func userLang(chatID int64, st *storage.Storage) i18n.Lang {
+	if val, _ := st.GetUserSetting(chatID, "lang"); val != "" {
+		if l, ok := i18n.Parse(val); ok {
+			return l
+		}
+	}
+	code, _ := st.GetUserSetting(chatID, "language_code")
+	return i18n.FromTelegram(code)
+}
+
+func (b *Bot) lang(chatID int64) i18n.Lang {
+	return userLang(chatID, b.Storage)
+}
+
+// rememberLanguageCode запоминает language_code из профиля Telegram, чтобы
+// фоновые рассылки шли на том же языке, что и ответы на команды
+func (b *Bot) rememberLanguageCode(chatID int64, from *tgbotapi.User) {
+	if from == nil || from.LanguageCode == "" {
+		return
+	}
+	if code, _ := b.Storage.GetUserSetting(chatID, "language_code"); code == from.LanguageCode {
+		return
+	}
+	if err := b.Storage.SetUserSetting(chatID, "language_code", from.LanguageCode); err != nil {
+		logging.Chat(chatID).Warn("не удалось сохранить язык Telegram", logging.Err(err))
+	}
+}
+
+// setLang меняет язык чата: код языка или auto — брать из Telegram
+func (b *Bot) setLang(chatID int64, arg string) (i18n.Lang, bool) {
+	arg = strings.ToLower(strings.TrimSpace(arg))
+	if arg == "auto" {
+		if err := b.Storage.SetUserSetting(chatID, "lang", ""); err != nil {
+			return "", false
+		}
+		return b.lang(chatID), true
+	}
+	l, ok := i18n.Parse(arg)
+	if !ok {
+		return "", false
+	}
+	if err := b.Storage.SetUserSetting(chatID, "lang", string(l)); err != nil {
+		return "", false
+	}
+	return l, true
+}
+
+// sendLang — /lang [ru|en|auto]: без аргумента показывает выбор кнопками
+func (b *Bot) sendLang(chatID int64, arg string) {
+	if strings.TrimSpace(arg) != "" {
+		l, ok := b.setLang(chatID, arg)
+		if !ok {
+			b.SendMessage(chatID, b.lang(chatID).T("lang.usage"))
+			return
+		}
+		b.SendMessage(chatID, l.T("lang.saved", i18n.Names[l]))
+		return
+	}
+
+	l := b.lang(chatID)
+	msg := tgbotapi.NewMessage(chatID, l.T("lang.current", i18n.Names[l]))
+	msg.ReplyMarkup = langKeyboard(l)
+	_, _ = b.send(msg)
+}
+
+func langKeyboard(l i18n.Lang) tgbotapi.InlineKeyboardMarkup {
+	var row []tgbotapi.InlineKeyboardButton
+	for _, lang := range i18n.Langs {
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(i18n.Names[lang], "lang:"+string(lang)))
+	}
+	return tgbotapi.NewInlineKeyboardMarkup(row,
+		tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData(l.T("lang.auto"), "lang:auto")))
+}
+
+func (b *Bot) handleLangCallback(cq *tgbotapi.CallbackQuery, arg string) {
+	chatID := cq.Message.Chat.ID
+	l, ok := b.setLang(chatID, arg)
+	if !ok {
+		b.answerCallback(cq.ID, "")
+		return
+	}
+	b.answerCallback(cq.ID, i18n.Names[l])
+	edit := tgbotapi.NewEditMessageTextAndMarkup(chatID, cq.Message.MessageID, l.T("lang.saved", i18n.Names[l]), langKeyboard(l))
+	_, _ = b.Api.Request(edit)
+}
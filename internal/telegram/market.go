@@ -0,0 +1,103 @@
+package telegram
+
+import (
+	"log/slog"
+	"strings"
+	"time"
+
+	"hhruBot/internal/hh"
+	"hhruBot/internal/logging"
+	"hhruBot/internal/market"
+	"hhruBot/internal/salary"
+	"hhruBot/internal/storage"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// recordMarket учитывает найденные вакансии в статистике рынка для /market.
+// Вакансию относим к тем тегам поиска, что встречаются в её названии или
+// описании; если не встретился ни один — ко всем тегам поиска.
+func recordMarket(st *storage.Storage, tags []string, vacancies []hh.Vacancy, rates salary.Rates) {
+	conv := salary.Converter{Rates: rates, Pref: salary.DefaultPreference()}
+	today := time.Now()
+	for _, v := range vacancies {
+		if v.Id == "" {
+			continue
+		}
+		sample := storage.MarketSample{Day: today, VacancyID: v.Id, Employer: v.Employer.Name}
+		if r, ok := conv.Convert(v.Salary); ok {
+			sample.Salary = monthlySalary(r)
+		}
+		for _, skill := range v.KeySkills {
+			sample.Skills = append(sample.Skills, skill.Name)
+		}
+
+		for _, tag := range vacancyTags(v, tags) {
+			for _, city := range []string{v.Area.Name, ""} {
+				sample.Tag, sample.City = market.Key(tag, city)
+				if err := st.RecordMarket(sample); err != nil {
+					slog.Debug("не удалось записать статистику рынка", logging.KeyVacancyID, v.Id, logging.Err(err))
+					return
+				}
+			}
+		}
+	}
+}
+
+// vacancyTags — теги поиска, к которым относится вакансия
+func vacancyTags(v hh.Vacancy, tags []string) []string {
+	text := strings.ToLower(v.Name + " " + v.SnippetText())
+	var matched []string
+	for _, tag := range tags {
+		if strings.Contains(text, strings.ToLower(tag)) {
+			matched = append(matched, tag)
+		}
+	}
+	if len(matched) == 0 {
+		return tags
+	}
+	return matched
+}
+
+// monthlySalary — одна оценка зарплаты из вилки: середина или известная граница
+func monthlySalary(r salary.Range) float64 {
+	if r.From > 0 && r.To > 0 {
+		return (r.From + r.To) / 2
+	}
+	return r.Upper()
+}
+
+// sendMarket присылает отчёт "/market golang Москва 30d" и график
+func (b *Bot) sendMarket(chatID int64, args string) {
+	l := b.lang(chatID)
+	q, err := market.ParseQuery(args)
+	if err != nil {
+		b.SendMessage(chatID, "❌ "+l.Err(err)+"\n"+l.T("market.example"))
+		return
+	}
+
+	tag, city := market.Key(q.Tag, q.City)
+	to := time.Now()
+	days, err := b.Storage.GetMarketDays(tag, city, to.AddDate(0, 0, -(q.Days-1)), to)
+	if err != nil {
+		b.SendMessage(chatID, l.T("market.load_error"))
+		return
+	}
+
+	report := market.Build(days)
+	b.SendMessage(chatID, report.Text(q, l))
+	if report.Total == 0 {
+		return
+	}
+
+	chart, err := market.Chart(report)
+	if err != nil {
+		logging.Chat(chatID).Warn("не удалось нарисовать график рынка", logging.Err(err))
+		return
+	}
+	photo := tgbotapi.NewPhoto(chatID, tgbotapi.FileBytes{Name: "market.png", Bytes: chart})
+	photo.Caption = l.T("market.chart_caption")
+	if _, err := b.Api.Send(photo); err != nil {
+		logging.Chat(chatID).Warn("не удалось отправить график рынка", logging.Err(err))
+	}
+}
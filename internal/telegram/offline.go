@@ -0,0 +1,95 @@
+package telegram
+
+import (
+	"time"
+
+	"hhruBot/internal/config"
+	"hhruBot/internal/hh"
+	"hhruBot/internal/notify"
+	"hhruBot/internal/sources"
+	"hhruBot/internal/storage"
+)
+
+// NewOffline собирает бота без подключения к Telegram — для подкоманд search
+// и check. Чекеры и таймеры не запускаются, сообщения не отправляются.
+func NewOffline(cfg *config.Config, storage *storage.Storage, hhClient *hh.Client) *Bot {
+	return &Bot{
+		Storage:           storage,
+		HHClient:          hhClient,
+		StopChans:         make(map[int64]chan struct{}),
+		EmployerStopChans: make(map[int64]chan struct{}),
+		Admins:            make(map[int64]bool),
+		Settings:          cfg.Search,
+		Sources:           sources.FromConfig(cfg, hhClient),
+		PublicURL:         cfg.PublicURL,
+	}
+}
+
+// UserSearches — поиски пользователя: основной из настроек и сохранённые
+func (b *Bot) UserSearches(chatID int64) []storage.SavedSearch {
+	return loadSearches(chatID, b.Storage)
+}
+
+// UserProfile — настройки оценки вакансий пользователя
+func (b *Bot) UserProfile(chatID int64) ProfileSettings {
+	return loadProfileSettings(chatID, b.Storage)
+}
+
+// SearchOnce ищет вакансии с теми же фильтрами, оценкой и склейкой дубликатов,
+// что и фоновая проверка, но без учёта уже отправленного: ничего не помечает
+// и не отправляет. Возвращает карточки и число вакансий от источников.
+func (b *Bot) SearchOnce(search storage.SavedSearch, settings ProfileSettings, from time.Time) ([]notify.Item, int, error) {
+	profile := buildScoringProfile(settings, loadCurrencyRates(b.HHClient, b.Storage))
+	groups, vacancies, err := b.matchSearch(0, search, b.searchQuery(search, from), profile)
+	items := make([]notify.Item, 0, len(groups))
+	for _, group := range groups {
+		items = append(items, groupItem(group, profile.Salary, !profile.Empty(), search.Name))
+	}
+	return items, len(vacancies), err
+}
+
+// CheckResult — итог пробной проверки одного поиска
+type CheckResult struct {
+	Search  storage.SavedSearch
+	Found   int           // вакансий вернули источники
+	Items   []notify.Item // карточки, которые ушли бы пользователю
+	Seen    int           // групп, целиком отправленных раньше
+	Reposts int           // перепубликаций, которые были бы пропущены
+	Err     error
+}
+
+// CheckFrom — с какого момента искал бы вакансии следующий цикл чекера
+func (b *Bot) CheckFrom(chatID int64) time.Time {
+	return b.checkFrom(chatID, b.userIntervalMin(chatID))
+}
+
+// DryRunCheck выполняет один цикл проверки чата, как чекер, но ничего не
+// отправляет, не помечает просмотренным и не пишет в статистику рынка
+func (b *Bot) DryRunCheck(chatID int64, from time.Time) []CheckResult {
+	searches := loadSearches(chatID, b.Storage)
+	profile := loadScoringProfile(chatID, b.HHClient, b.Storage)
+
+	results := make([]CheckResult, 0, len(searches))
+	for _, search := range searches {
+		label := ""
+		if len(searches) > 1 {
+			label = search.Name
+		}
+		r := CheckResult{Search: search}
+		groups, vacancies, err := b.matchSearch(chatID, search, b.searchQuery(search, from), profile)
+		r.Found, r.Err = len(vacancies), err
+		for _, group := range groups {
+			p, ok := prepareGroup(searchSeen{b.Storage, chatID}, profile.Salary, group, !profile.Empty(), label)
+			switch {
+			case !ok:
+				r.Seen++
+			case p.repost:
+				r.Reposts++
+			default:
+				r.Items = append(r.Items, p.item)
+			}
+		}
+		results = append(results, r)
+	}
+	return results
+}
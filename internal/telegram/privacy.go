@@ -0,0 +1,147 @@
+package telegram
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"hhruBot/internal/logging"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Запросы субъекта персональных данных (152-ФЗ): выгрузка всего, что бот
+// хранит о чате (/mydata), и удаление (/deleteme, у администратора — /user <id> delete)
+
+// sendMyData присылает JSON-архив со всеми данными чата. Архив группы
+// (в нём токен лент) уходит в личку тому, кто выполнил команду.
+func (b *Bot) sendMyData(message *tgbotapi.Message) {
+	chatID := message.Chat.ID
+	l := b.lang(chatID)
+	to, ok := privateRecipient(message)
+	if !ok {
+		b.SendMessage(chatID, l.T("private.unavailable"))
+		return
+	}
+	caption := l.T("mydata.caption")
+	if to != chatID {
+		caption = l.T("private.for_chat", message.Chat.Title) + "\n" + caption
+	}
+	if err := b.sendUserData(to, chatID, caption); err != nil {
+		if to != chatID {
+			b.SendMessage(chatID, l.T("private.failed"))
+		}
+		return
+	}
+	if to != chatID {
+		b.SendMessage(chatID, l.T("private.sent"))
+	}
+}
+
+// exportUser присылает администратору архив данных пользователя по запросу субъекта
+func (b *Bot) exportUser(adminChatID, userID int64) {
+	_ = b.sendUserData(adminChatID, userID, b.lang(adminChatID).T("mydata.user_caption", userID, userID))
+}
+
+// sendUserData отправляет архив данных userID в чат toChatID. Ошибку возвращает,
+// только если в toChatID не удалось написать: о сбое выгрузки там уже сообщено.
+func (b *Bot) sendUserData(toChatID, userID int64, caption string) error {
+	data, err := b.Storage.ExportUser(userID)
+	if err != nil {
+		logging.Chat(userID).Error("не удалось выгрузить данные пользователя", logging.Err(err))
+		b.SendMessage(toChatID, b.lang(toChatID).T("mydata.error"))
+		return nil
+	}
+	body, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		logging.Chat(userID).Error("не удалось выгрузить данные пользователя", logging.Err(err))
+		b.SendMessage(toChatID, b.lang(toChatID).T("mydata.error"))
+		return nil
+	}
+
+	doc := tgbotapi.NewDocument(toChatID, tgbotapi.FileBytes{Name: fmt.Sprintf("mydata-%d.json", userID), Bytes: body})
+	doc.Caption = caption
+	if _, err := b.Api.Send(doc); err != nil {
+		logging.Chat(toChatID).Warn("не удалось отправить архив данных", logging.Err(err))
+		return err
+	}
+	return nil
+}
+
+// askDeleteMe просит подтвердить удаление данных
+func (b *Bot) askDeleteMe(chatID int64) {
+	l := b.lang(chatID)
+	msg := tgbotapi.NewMessage(chatID, l.T("deleteme.confirm"))
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(l.T("deleteme.button.all"), "deleteme:confirm"),
+		tgbotapi.NewInlineKeyboardButtonData(l.T("deleteme.button.cancel"), "deleteme:cancel"),
+	))
+	_, _ = b.send(msg)
+}
+
+// handleDeleteMeCallback подтверждает или отменяет удаление данных
+func (b *Bot) handleDeleteMeCallback(cq *tgbotapi.CallbackQuery, arg string) {
+	chatID := cq.Message.Chat.ID
+	// язык берём до удаления — вместе с данными удалится и выбор языка
+	l := b.lang(chatID)
+	b.editKeyboard(cq.Message, tgbotapi.NewInlineKeyboardMarkup())
+	if arg != "confirm" {
+		b.answerCallback(cq.ID, l.T("deleteme.cancelled"))
+		return
+	}
+
+	if err := b.deleteUser(chatID); err != nil {
+		b.answerCallback(cq.ID, l.T("deleteme.error"))
+		return
+	}
+	b.answerCallback(cq.ID, l.T("deleteme.deleted"))
+	b.SendMessage(chatID, l.T("deleteme.done"))
+}
+
+// askDeleteUser просит администратора подтвердить удаление данных пользователя
+func (b *Bot) askDeleteUser(adminChatID, userID int64) {
+	l := b.lang(adminChatID)
+	id := strconv.FormatInt(userID, 10)
+	msg := tgbotapi.NewMessage(adminChatID, l.T("deleteuser.confirm", userID))
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(l.T("deleteuser.button"), "deleteuser:"+id),
+		tgbotapi.NewInlineKeyboardButtonData(l.T("deleteme.button.cancel"), "deleteuser:cancel"),
+	))
+	_, _ = b.send(msg)
+}
+
+// handleDeleteUserCallback удаляет данные пользователя по кнопке администратора
+func (b *Bot) handleDeleteUserCallback(cq *tgbotapi.CallbackQuery, arg string) {
+	if !b.isAdmin(cq.From.ID) {
+		b.answerCallback(cq.ID, "")
+		return
+	}
+	l := b.lang(cq.Message.Chat.ID)
+	b.editKeyboard(cq.Message, tgbotapi.NewInlineKeyboardMarkup())
+	userID, err := strconv.ParseInt(arg, 10, 64)
+	if err != nil {
+		b.answerCallback(cq.ID, l.T("deleteme.cancelled"))
+		return
+	}
+
+	if err := b.deleteUser(userID); err != nil {
+		b.answerCallback(cq.ID, l.T("deleteme.error"))
+		return
+	}
+	b.answerCallback(cq.ID, l.T("deleteme.deleted"))
+	slog.Info("администратор удалил данные пользователя", "admin_id", cq.From.ID, logging.KeyChatID, userID)
+	b.SendMessage(cq.Message.Chat.ID, l.T("deleteuser.done", userID))
+}
+
+// deleteUser останавливает проверки чата и удаляет все его данные
+func (b *Bot) deleteUser(chatID int64) error {
+	b.stopChecker(chatID)
+	b.stopEmployerWatcher(chatID)
+	if err := b.Storage.DeleteUser(chatID); err != nil {
+		logging.Chat(chatID).Error("не удалось удалить данные пользователя", logging.Err(err))
+		return err
+	}
+	logging.Chat(chatID).Info("данные пользователя удалены")
+	return nil
+}
@@ -0,0 +1,191 @@
+package telegram
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"hhruBot/internal/i18n"
+	"hhruBot/internal/logging"
+	"hhruBot/internal/pipeline"
+	"hhruBot/internal/storage"
+)
+
+// Виды таймеров
+const (
+	timerFollowUp = "followup"
+	timerRemind   = "remind"
+)
+
+const (
+	timerPollInterval   = 30 * time.Second
+	defaultFollowUpDays = 7
+	defaultTimezone     = "Europe/Moscow"
+	remindLayout        = "2006-01-02 15:04"
+)
+
+// StartTimerLoop раз в timerPollInterval забирает наступившие таймеры из хранилища и выполняет их
+func StartTimerLoop(storage *storage.Storage, bot *Bot) {
+	ticker := time.NewTicker(timerPollInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		ids, err := storage.DueTimers(time.Now())
+		if err != nil {
+			slog.Warn("не удалось получить таймеры", logging.Err(err))
+			continue
+		}
+		for _, id := range ids {
+			t, ok, err := storage.ClaimTimer(id)
+			if err != nil {
+				slog.Warn("не удалось забрать таймер", "timer_id", id, logging.Err(err))
+				continue
+			}
+			if ok {
+				bot.fireTimer(*t)
+			}
+		}
+	}
+}
+
+func (b *Bot) fireTimer(t storage.Timer) {
+	l := b.lang(t.ChatID)
+	switch t.Kind {
+	case timerFollowUp:
+		saved, err := b.Storage.GetSavedVacancy(t.ChatID, t.VacancyID)
+		if err != nil || saved.Status != string(pipeline.Applied) {
+			// статус уже сменился — напоминать не о чем
+			return
+		}
+		b.SendMessage(t.ChatID, l.T("followup.fired", saved.Name, saved.Id, t.Text))
+
+	case timerRemind:
+		text := l.T("remind.fired")
+		if t.VacancyID != "" {
+			if saved, err := b.Storage.GetSavedVacancy(t.ChatID, t.VacancyID); err == nil {
+				text += ": " + saved.Name
+			}
+			text += "\nhttps://hh.ru/vacancy/" + t.VacancyID
+		}
+		if t.Text != "" {
+			text += "\n" + t.Text
+		}
+		b.SendMessage(t.ChatID, text)
+
+	default:
+		slog.Warn("неизвестный вид таймера", "kind", t.Kind, logging.KeyChatID, t.ChatID)
+	}
+}
+
+// scheduleFollowUp ставит напоминание «нет ответа N дней» для вакансии со статусом «Отклик»
+func (b *Bot) scheduleFollowUp(chatID int64, vacancyID string) {
+	days := defaultFollowUpDays
+	if val, err := b.Storage.GetUserSetting(chatID, "followup_days"); err == nil {
+		if n, err := strconv.Atoi(val); err == nil {
+			days = n
+		}
+	}
+	if days <= 0 {
+		return
+	}
+
+	l := b.lang(chatID)
+	t := storage.Timer{
+		Id:        newTimerID(),
+		ChatID:    chatID,
+		Kind:      timerFollowUp,
+		VacancyID: vacancyID,
+		Text:      l.T("followup.text", l.N("days", days)),
+		Due:       time.Now().AddDate(0, 0, days),
+	}
+	if err := b.Storage.ScheduleTimer(t); err != nil {
+		logging.Chat(chatID).Warn("не удалось запланировать напоминание", logging.KeyVacancyID, vacancyID, logging.Err(err))
+	}
+}
+
+// scheduleReminder разбирает "/remind <вакансия> 2026-10-21 14:00 [текст]" во времени пользователя
+func (b *Bot) scheduleReminder(chatID int64, args string) (*storage.Timer, error) {
+	fields := strings.Fields(args)
+	if len(fields) < 3 {
+		return nil, i18n.Errorf("remind.not_enough_args")
+	}
+
+	vacancyID, err := parseVacancyID(fields[0])
+	if err != nil {
+		return nil, err
+	}
+	if vacancyID == "" {
+		return nil, i18n.Errorf("remind.bad_vacancy", fields[0])
+	}
+
+	loc := b.userLocation(chatID)
+	due, err := time.ParseInLocation(remindLayout, fields[1]+" "+fields[2], loc)
+	if err != nil {
+		return nil, i18n.Errorf("remind.bad_date")
+	}
+	if due.Before(time.Now()) {
+		return nil, i18n.Errorf("remind.past")
+	}
+
+	t := storage.Timer{
+		Id:        newTimerID(),
+		ChatID:    chatID,
+		Kind:      timerRemind,
+		VacancyID: vacancyID,
+		Text:      strings.Join(fields[3:], " "),
+		Due:       due,
+	}
+	if err := b.Storage.ScheduleTimer(t); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// userLocation возвращает часовой пояс пользователя (по умолчанию — Москва)
+func (b *Bot) userLocation(chatID int64) *time.Location {
+	name, err := b.Storage.GetUserSetting(chatID, "timezone")
+	if err != nil || name == "" {
+		name = defaultTimezone
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// sendReminders показывает запланированные напоминания пользователя
+func (b *Bot) sendReminders(chatID int64) {
+	l := b.lang(chatID)
+	timers, err := b.Storage.GetUserTimers(chatID)
+	if err != nil {
+		b.SendMessage(chatID, l.T("reminders.load_error"))
+		return
+	}
+	if len(timers) == 0 {
+		b.SendMessage(chatID, l.T("reminders.empty"))
+		return
+	}
+
+	loc := b.userLocation(chatID)
+	var sb strings.Builder
+	sb.WriteString(l.T("reminders.title") + "\n")
+	for _, t := range timers {
+		sb.WriteString(l.T("reminders.item", t.Due.In(loc).Format(l.T("layout.datetime")), l.T("reminders.kind."+t.Kind), t.VacancyID))
+		if t.Text != "" && t.Kind == timerRemind {
+			fmt.Fprintf(&sb, ": %s", t.Text)
+		}
+		fmt.Fprintf(&sb, " (/unremind %s)\n", t.Id)
+	}
+	b.SendMessage(chatID, sb.String())
+}
+
+func newTimerID() string {
+	buf := make([]byte, 6)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
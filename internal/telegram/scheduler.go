@@ -1,17 +1,35 @@
 package telegram
 
 import (
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"strconv"
 	"strings"
 	"time"
 
-	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"hhruBot/internal/dedup"
 	"hhruBot/internal/hh"
+	"hhruBot/internal/i18n"
+	"hhruBot/internal/logging"
+	"hhruBot/internal/metrics"
+	"hhruBot/internal/notify"
+	"hhruBot/internal/salary"
+	"hhruBot/internal/scoring"
+	"hhruBot/internal/sources"
 	"hhruBot/internal/storage"
 )
 
+// errMaintenance — проверка пропущена: администратор включил режим обслуживания (/pauseall)
+var errMaintenance = errors.New("режим обслуживания")
+
+// errPaused — проверка пропущена: пользователя поставили на паузу в обход
+// чекера, например подкомандой users
+var errPaused = errors.New("пользователь на паузе")
+
+// Как часто обновляем курсы валют из справочников hh.ru
+const currencyRatesTTL = 12 * time.Hour
+
 func StartUserVacancyChecker(
 	chatID int64,
 	hhClient *hh.Client,
@@ -19,23 +37,19 @@ func StartUserVacancyChecker(
 	bot *Bot,
 	stopCh chan struct{},
 ) {
-	intervalMin, err := storage.GetUserInterval(chatID)
-	if err != nil || intervalMin <= 0 {
-		intervalMin = 30
-	}
+	metrics.ActiveCheckers.Inc()
+	defer metrics.ActiveCheckers.Dec()
 
-	// Сначала пытаемся восстановить lastChecked из хранилища
-	lastChecked, err := storage.GetLastChecked(chatID)
-	if err != nil {
-		// если нет записи — смотрим назад на один интервал
-		lastChecked = time.Now().Add(-time.Duration(intervalMin) * time.Minute)
-	}
+	intervalMin := bot.userIntervalMin(chatID)
+	lastChecked := bot.checkFrom(chatID, intervalMin)
 
 	// Выполняем немедленную проверку (чтобы не ждать первый тик)
 	from := lastChecked
 	now := time.Now()
-	if err := checkVacancies(chatID, hhClient, storage, bot, from); err != nil {
-		log.Printf("❌ Ошибка при начальной проверке вакансий [%d]: %v", chatID, err)
+	if err := checkVacancies(chatID, hhClient, storage, bot, from); errors.Is(err, errMaintenance) || errors.Is(err, errPaused) {
+		// в режиме обслуживания lastChecked не двигаем, чтобы не потерять вакансии
+	} else if err != nil {
+		logging.Chat(chatID).Error("ошибка при начальной проверке вакансий", logging.KeyHHStatus, hh.StatusOf(err), logging.Err(err))
 	} else {
 		// обновляем lastChecked только при успешной проверке
 		storage.SetLastChecked(chatID, now)
@@ -48,12 +62,16 @@ func StartUserVacancyChecker(
 	for {
 		select {
 		case <-stopCh:
-			log.Printf("Остановлен чекер для chatID %d", chatID)
+			logging.Chat(chatID).Info("остановлен чекер")
 			return
 		case <-ticker.C:
 			now := time.Now()
-			if err := checkVacancies(chatID, hhClient, storage, bot, lastChecked); err != nil {
-				log.Printf("❌ Ошибка при проверке вакансий [%d]: %v", chatID, err)
+			err := checkVacancies(chatID, hhClient, storage, bot, lastChecked)
+			if errors.Is(err, errMaintenance) || errors.Is(err, errPaused) {
+				continue
+			}
+			if err != nil {
+				logging.Chat(chatID).Error("ошибка при проверке вакансий", logging.KeyHHStatus, hh.StatusOf(err), logging.Err(err))
 				// при ошибке lastChecked не меняем — на следующем тике попробуем снова
 				continue
 			}
@@ -64,6 +82,25 @@ func StartUserVacancyChecker(
 	}
 }
 
+// userIntervalMin — интервал проверки пользователя в минутах
+func (b *Bot) userIntervalMin(chatID int64) int {
+	intervalMin, err := b.Storage.GetUserInterval(chatID)
+	if err != nil || intervalMin < b.minIntervalMin() {
+		intervalMin = b.defaultIntervalMin()
+	}
+	return intervalMin
+}
+
+// checkFrom — с какого момента искать вакансии: с последней успешной проверки,
+// а если её не было — за один интервал
+func (b *Bot) checkFrom(chatID int64, intervalMin int) time.Time {
+	lastChecked, err := b.Storage.GetLastChecked(chatID)
+	if err != nil {
+		return time.Now().Add(-time.Duration(intervalMin) * time.Minute)
+	}
+	return lastChecked
+}
+
 func checkVacancies(
 	chatID int64,
 	hhClient *hh.Client,
@@ -71,43 +108,354 @@ func checkVacancies(
 	bot *Bot,
 	from time.Time,
 ) error {
-	tagsStr, _ := storage.GetUserSetting(chatID, "tags")
-	citiesStr, _ := storage.GetUserSetting(chatID, "cities")
+	if storage.IsMaintenance() {
+		return errMaintenance
+	}
+	if paused, _ := storage.IsUserPaused(chatID); paused {
+		return errPaused
+	}
+	storage.IncrStat("checks")
 
-	tags := parseCSV(tagsStr)
-	cities := parseCSV(citiesStr)
+	searches := loadSearches(chatID, storage)
+	profile := loadScoringProfile(chatID, hhClient, storage)
 
-	vacancies, err := hhClient.GetVacancies(tags, cities, from)
-	if err != nil {
-		return err
+	// Ошибку источника возвращаем, чтобы lastChecked не сдвинулся: вакансии
+	// остальных источников уже помечены просмотренными и повторно не придут
+	var found int
+	var errs []error
+	for _, search := range searches {
+		// Название поиска показываем в карточке, только если поисков несколько
+		label := ""
+		if len(searches) > 1 {
+			label = search.Name
+		}
+		n, err := checkSearch(chatID, search, label, storage, bot, profile, from)
+		found += n
+		if err != nil {
+			logging.Chat(chatID).Warn("ошибка поиска вакансий", logging.KeySearchID, search.Id, logging.KeyHHStatus, hh.StatusOf(err), logging.Err(err))
+			errs = append(errs, err)
+		}
+	}
+
+	if found == 0 && len(errs) == 0 {
+		bot.SendMessage(chatID, profile.Lang.T("check.nothing_found"))
+	}
+	return errors.Join(errs...)
+}
+
+// checkSearch опрашивает источники одного поиска и отправляет новые вакансии.
+// Возвращает число найденных вакансий.
+func checkSearch(
+	chatID int64,
+	search storage.SavedSearch,
+	label string,
+	storage *storage.Storage,
+	bot *Bot,
+	profile scoring.Profile,
+	from time.Time,
+) (int, error) {
+	q := bot.searchQuery(search, from)
+	groups, vacancies, err := bot.matchSearch(chatID, search, q, profile)
+	if len(vacancies) == 0 {
+		return 0, err
+	}
+	recordMarket(storage, q.Tags, vacancies, profile.Salary.Rates)
+
+	pending := collectPending(chatID, searchSeen{storage, chatID}, profile.Salary, groups, !profile.Empty(), label)
+	sendVacancies(chatID, storage, bot, &search, pending)
+
+	return len(vacancies), err
+}
+
+// searchQuery — запрос к источникам; без тегов ищем по запросу по умолчанию
+func (b *Bot) searchQuery(search storage.SavedSearch, from time.Time) sources.Query {
+	q := sources.Query{Tags: search.Tags, Cities: search.Cities, From: from}
+	if len(q.Tags) == 0 {
+		q.Tags = []string{b.Settings.FallbackTag}
 	}
+	return q
+}
 
+// matchSearch опрашивает источники поиска, оценивает вакансии и собирает
+// дубликаты в группы, лучшие — первыми. Ничего не помечает и не отправляет.
+func (b *Bot) matchSearch(chatID int64, search storage.SavedSearch, q sources.Query, profile scoring.Profile) ([][]scoring.Scored, []hh.Vacancy, error) {
+	vacancies, err := b.Sources.Search(searchSources(search), q)
+	metrics.VacanciesFound.Add(float64(len(vacancies)))
 	if len(vacancies) == 0 {
-		bot.SendMessage(chatID, "🔍 Новые вакансии не были найдены.")
-		return nil
+		return nil, nil, err
 	}
 
-	for _, v := range vacancies {
-		vacID, err := strconv.Atoi(v.Id)
-		if err != nil || storage.AlreadySeen(vacID) {
+	var ranked []scoring.Scored
+	if profile.Empty() {
+		for _, v := range vacancies {
+			sv := scoring.Scored{Vacancy: v}
+			sv.Salary, sv.HasSalary = profile.Salary.Convert(v.Salary)
+			ranked = append(ranked, sv)
+		}
+	} else {
+		if profile.NeedsDetails() {
+			for i, v := range vacancies {
+				full, err := b.Sources.Details(v)
+				if errors.Is(err, sources.ErrUnsupported) {
+					continue
+				}
+				if err != nil {
+					logging.Chat(chatID).Warn("не удалось загрузить вакансию", logging.KeySearchID, search.Id, logging.KeyVacancyID, v.Id, logging.KeyHHStatus, hh.StatusOf(err), logging.Err(err))
+					continue
+				}
+				vacancies[i].KeySkills = full.KeySkills
+			}
+		}
+		ranked = profile.Rank(vacancies)
+	}
+	return groupDuplicates(ranked), vacancies, err
+}
+
+func groupDuplicates(items []scoring.Scored) [][]scoring.Scored {
+	return dedup.Group(items, func(sv scoring.Scored) hh.Vacancy { return sv.Vacancy })
+}
+
+// seenSet — где помним отправленные вакансии и их отпечатки
+type seenSet interface {
+	AlreadySeen(vacancyID string) bool
+	MarkAsSeen(vacancyID string)
+	GetFingerprint(fingerprint string) (string, bool)
+	MarkFingerprint(fingerprint, snippet string)
+}
+
+// searchSeen — вакансии поисков по тегам: ID общие для всех чатов, отпечатки — свои у чата
+type searchSeen struct {
+	st     *storage.Storage
+	chatID int64
+}
+
+func (s searchSeen) AlreadySeen(id string) bool { return s.st.AlreadySeen(id) }
+func (s searchSeen) MarkAsSeen(id string)       { s.st.MarkAsSeen(id) }
+func (s searchSeen) GetFingerprint(fp string) (string, bool) {
+	return s.st.GetFingerprint(s.chatID, fp)
+}
+func (s searchSeen) MarkFingerprint(fp, snippet string) { s.st.MarkFingerprint(s.chatID, fp, snippet) }
+
+// employerSeen — вакансии отслеживаемых работодателей, отдельно для каждого чата
+type employerSeen struct {
+	st     *storage.Storage
+	chatID int64
+}
+
+func (s employerSeen) AlreadySeen(id string) bool { return s.st.EmployerVacancySeen(s.chatID, id) }
+func (s employerSeen) MarkAsSeen(id string)       { s.st.MarkEmployerVacancySeen(s.chatID, id) }
+func (s employerSeen) GetFingerprint(fp string) (string, bool) {
+	return s.st.GetEmployerFingerprint(s.chatID, fp)
+}
+func (s employerSeen) MarkFingerprint(fp, snippet string) {
+	s.st.MarkEmployerFingerprint(s.chatID, fp, snippet)
+}
+
+// pendingVacancy — карточка группы дубликатов, ожидающая доставки
+type pendingVacancy struct {
+	item     notify.Item
+	markSeen func()
+	repost   bool // перепубликация уже отправленной вакансии под новым ID
+}
+
+// collectPending готовит карточки для групп дубликатов. Перепубликации сразу
+// помечаются просмотренными и не отправляются.
+func collectPending(chatID int64, seen seenSet, conv salary.Converter, groups [][]scoring.Scored, withScore bool, searchName string) []pendingVacancy {
+	var pending []pendingVacancy
+	for _, group := range groups {
+		p, ok := prepareGroup(seen, conv, group, withScore, searchName)
+		if !ok {
 			continue
 		}
+		if p.repost {
+			logging.Chat(chatID).Debug("перепубликация вакансии, пропускаем", logging.KeyVacancyID, p.item.Vacancy.Id)
+			p.markSeen()
+			continue
+		}
+		pending = append(pending, p)
+	}
+	return pending
+}
 
-		url := fmt.Sprintf("https://hh.ru/vacancy/%s", v.Id)
-		text := fmt.Sprintf("❤ *Новая вакансия:* [%s](%s)\n🏙️ Город: %s", v.Name, url, v.Area.Name)
+// prepareGroup готовит одну карточку на группу дубликатов. Возвращает false, если
+// все вакансии группы уже отправлялись. Только читает хранилище: вакансии
+// помечаются просмотренными вызовом markSeen.
+func prepareGroup(seen seenSet, conv salary.Converter, group []scoring.Scored, withScore bool, searchName string) (pendingVacancy, bool) {
+	var fresh []scoring.Scored
+	var ids []string
+	for _, sv := range group {
+		if sv.Vacancy.Id == "" || seen.AlreadySeen(sv.Vacancy.Id) {
+			continue
+		}
+		fresh = append(fresh, sv)
+		ids = append(ids, sv.Vacancy.Id)
+	}
+	if len(fresh) == 0 {
+		return pendingVacancy{}, false
+	}
 
-		msg := tgbotapi.NewMessage(chatID, text)
-		msg.ParseMode = "Markdown"
+	v := fresh[0].Vacancy
+	fingerprint := dedup.Fingerprint(v)
+	markSeen := func() {
+		for _, id := range ids {
+			seen.MarkAsSeen(id)
+		}
+		seen.MarkFingerprint(fingerprint, v.SnippetText())
+	}
+
+	// перепубликацию узнаём по сниппету: полное описание при поиске не запрашивается
+	repost := false
+	if prev, ok := seen.GetFingerprint(fingerprint); ok {
+		repost = dedup.Similarity(prev, v.SnippetText()) >= dedup.SimilarityThreshold
+	}
+	item := groupItem(fresh, conv, withScore, searchName)
+	return pendingVacancy{item: item, markSeen: markSeen, repost: repost}, true
+}
+
+// groupItem — карточка группы дубликатов: лучшая вакансия и города остальных
+func groupItem(group []scoring.Scored, conv salary.Converter, withScore bool, searchName string) notify.Item {
+	sv := group[0]
+	v := sv.Vacancy
+	item := notify.Item{
+		Vacancy:    v,
+		URL:        v.URL,
+		OtherAreas: otherAreas(group),
+		Score:      sv.Result.Score,
+		Reasons:    sv.Result.Reasons,
+		WithScore:  withScore,
+		Search:     searchName,
+	}
+	if item.URL == "" {
+		item.URL = fmt.Sprintf("https://hh.ru/vacancy/%s", v.Id)
+	}
+	if src := sources.SourceOf(v); src != sources.HH {
+		item.Source = sources.Title(src, conv.Pref.Lang)
+	}
+	if sv.HasSalary {
+		item.Salary = conv.Format(sv.Salary)
+	}
+	return item
+}
+
+// sendVacancies доставляет карточки в каналы пользователя или поиска и помечает
+// просмотренными те, что дошли хотя бы до одного канала. Доставленные вакансии
+// попадают в историю (/history, ленты /feed). search == nil — вакансии работодателей.
+func sendVacancies(chatID int64, storage *storage.Storage, bot *Bot, search *storage.SavedSearch, pending []pendingVacancy) {
+	if len(pending) == 0 {
+		return
+	}
+	var channelIDs []string
+	if search != nil {
+		channelIDs = search.Channels
+	}
+	items := make([]notify.Item, len(pending))
+	for i, p := range pending {
+		items[i] = p.item
+	}
 
-		if _, err := bot.Api.Send(msg); err != nil {
-			log.Printf("❌ Не удалось отправить вакансию: %v", err)
+	for i, ok := range bot.deliver(chatID, channelIDs, items) {
+		if !ok {
 			continue
 		}
+		storage.IncrStat("sent")
+		metrics.VacanciesSent.Inc()
+		pending[i].markSeen()
+		bot.recordSent(chatID, search, pending[i].item)
+	}
+}
+
+// otherAreas возвращает города остальных вакансий группы без повторов
+func otherAreas(group []scoring.Scored) []string {
+	seen := map[string]bool{group[0].Vacancy.Area.Name: true}
+	var areas []string
+	for _, sv := range group[1:] {
+		name := sv.Vacancy.Area.Name
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		areas = append(areas, name)
+	}
+	return areas
+}
+
+// ProfileSettings — настройки оценки вакансий в том виде, в каком их хранит
+// бот и вводит пользователь (/keywords, /skills, /minsalary, ...)
+type ProfileSettings struct {
+	Keywords    string
+	Skills      string
+	MinSalary   string
+	Threshold   string
+	Currency    string
+	SalaryBasis string    // net или gross
+	Lang        i18n.Lang // язык причин оценки и подписей зарплаты
+}
+
+// loadProfileSettings читает настройки оценки пользователя
+func loadProfileSettings(chatID int64, storage *storage.Storage) ProfileSettings {
+	setting := func(key string) string {
+		val, _ := storage.GetUserSetting(chatID, key)
+		return val
+	}
+	return ProfileSettings{
+		Keywords:    setting("keywords"),
+		Skills:      setting("skills"),
+		MinSalary:   setting("min_salary"),
+		Threshold:   setting("threshold"),
+		Currency:    setting("currency"),
+		SalaryBasis: setting("salary_basis"),
+		Lang:        userLang(chatID, storage),
+	}
+}
 
-		storage.MarkAsSeen(vacID)
+// loadScoringProfile собирает профиль оценки из настроек пользователя
+func loadScoringProfile(chatID int64, hhClient *hh.Client, storage *storage.Storage) scoring.Profile {
+	return buildScoringProfile(loadProfileSettings(chatID, storage), loadCurrencyRates(hhClient, storage))
+}
+
+func buildScoringProfile(s ProfileSettings, rates salary.Rates) scoring.Profile {
+	var p scoring.Profile
+	p.Salary = buildSalaryConverter(s, rates)
+	p.Positive, p.Negative, _ = scoring.ParseWeighted(s.Keywords, scoring.DefaultKeywordWeight)
+	p.Skills, _, _ = scoring.ParseWeighted(s.Skills, scoring.DefaultSkillWeight)
+	p.MinSalary, _ = strconv.Atoi(s.MinSalary)
+	p.Threshold, _ = strconv.Atoi(s.Threshold)
+	p.Lang = s.Lang
+	return p
+}
+
+// loadSalaryConverter собирает курсы валют и предпочтения пользователя по зарплате
+func loadSalaryConverter(chatID int64, hhClient *hh.Client, storage *storage.Storage) salary.Converter {
+	return buildSalaryConverter(loadProfileSettings(chatID, storage), loadCurrencyRates(hhClient, storage))
+}
+
+func buildSalaryConverter(s ProfileSettings, rates salary.Rates) salary.Converter {
+	conv := salary.Converter{Rates: rates, Pref: salary.DefaultPreference()}
+	conv.Pref.Lang = s.Lang
+	if s.Currency != "" {
+		conv.Pref.Currency = s.Currency
+	}
+	if s.SalaryBasis != "" {
+		conv.Pref.Net = s.SalaryBasis != "gross"
+	}
+	return conv
+}
+
+// loadCurrencyRates берёт курсы из кэша в хранилище, а при его отсутствии обновляет их с hh.ru
+func loadCurrencyRates(hhClient *hh.Client, storage *storage.Storage) salary.Rates {
+	if rates, err := storage.GetCurrencyRates(); err == nil && len(rates) > 0 {
+		return rates
 	}
 
-	return nil
+	rates, err := hhClient.GetCurrencyRates()
+	if err != nil {
+		slog.Warn("не удалось обновить курсы валют", logging.KeyHHStatus, hh.StatusOf(err), logging.Err(err))
+		return salary.DefaultRates()
+	}
+	if err := storage.SetCurrencyRates(rates, currencyRatesTTL); err != nil {
+		slog.Warn("не удалось сохранить курсы валют", logging.Err(err))
+	}
+	return rates
 }
 
 func parseCSV(input string) []string {
@@ -0,0 +1,214 @@
+package telegram
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"slices"
+	"strings"
+
+	"hhruBot/internal/i18n"
+	"hhruBot/internal/sources"
+	"hhruBot/internal/storage"
+)
+
+// mainSearchID — основной поиск: теги и города из /tags и /city
+const mainSearchID = "main"
+
+// Сколько дополнительных поисков может сохранить пользователь
+const maxSavedSearches = 10
+
+// loadSearches возвращает основной поиск пользователя и его сохранённые поиски
+func loadSearches(chatID int64, st *storage.Storage) []storage.SavedSearch {
+	searches := []storage.SavedSearch{mainSearch(chatID, st)}
+	saved, err := st.GetSavedSearches(chatID)
+	if err == nil {
+		searches = append(searches, saved...)
+	}
+	return searches
+}
+
+// mainSearch собирает основной поиск из настроек пользователя
+func mainSearch(chatID int64, st *storage.Storage) storage.SavedSearch {
+	tags, _ := st.GetUserSetting(chatID, "tags")
+	cities, _ := st.GetUserSetting(chatID, "cities")
+	srcs, _ := st.GetUserSetting(chatID, "sources")
+	return storage.SavedSearch{
+		Id:      mainSearchID,
+		Name:    userLang(chatID, st).T("search.main"),
+		Tags:    parseCSV(tags),
+		Cities:  parseCSV(cities),
+		Sources: parseCSV(srcs),
+	}
+}
+
+// searchSources — источники поиска; по умолчанию только hh.ru
+func searchSources(search storage.SavedSearch) []string {
+	if len(search.Sources) == 0 {
+		return []string{sources.HH}
+	}
+	return search.Sources
+}
+
+// inputError — ошибка в данных пользователя, её текст показываем как есть.
+// Остальные ошибки — сбои хранилища.
+type inputError struct {
+	err error
+}
+
+func (e *inputError) Error() string { return e.err.Error() }
+func (e *inputError) Unwrap() error { return e.err }
+
+// invalidf — ошибка ввода с текстом из каталога сообщений
+func invalidf(key string, args ...any) error {
+	return &inputError{err: i18n.Errorf(key, args...)}
+}
+
+// addSearch разбирает "/search_add Название | теги | города" и сохраняет поиск
+func (b *Bot) addSearch(chatID int64, args string) (*storage.SavedSearch, error) {
+	parts := strings.Split(args, "|")
+	for len(parts) < 3 {
+		parts = append(parts, "")
+	}
+	return b.createSearch(chatID, storage.SavedSearch{
+		Name:   strings.TrimSpace(parts[0]),
+		Tags:   parseCSV(parts[1]),
+		Cities: parseCSV(parts[2]),
+	})
+}
+
+// createSearch проверяет и сохраняет новый поиск; общий путь для Telegram и HTTP API
+func (b *Bot) createSearch(chatID int64, search storage.SavedSearch) (*storage.SavedSearch, error) {
+	search.Id = newSearchID()
+	if err := b.validateSearch(chatID, search); err != nil {
+		return nil, err
+	}
+
+	saved, err := b.Storage.GetSavedSearches(chatID)
+	if err != nil {
+		return nil, i18n.Errorf("search.load_failed")
+	}
+	if len(saved) >= maxSavedSearches {
+		return nil, invalidf("search.too_many", maxSavedSearches)
+	}
+
+	if err := b.Storage.SaveSearch(chatID, search); err != nil {
+		return nil, i18n.Errorf("search.save_failed")
+	}
+	return &search, nil
+}
+
+// updateSearch проверяет и сохраняет изменённый поиск. Основной поиск хранится
+// в настройках пользователя, его название не меняется.
+func (b *Bot) updateSearch(chatID int64, search storage.SavedSearch) error {
+	if err := b.validateSearch(chatID, search); err != nil {
+		return err
+	}
+	if search.Id != mainSearchID {
+		if err := b.Storage.SaveSearch(chatID, search); err != nil {
+			return i18n.Errorf("search.save_failed")
+		}
+		return nil
+	}
+
+	if len(search.Channels) > 0 {
+		return invalidf("search.main_channels")
+	}
+	for key, values := range map[string][]string{"tags": search.Tags, "cities": search.Cities, "sources": search.Sources} {
+		if err := b.Storage.SetUserSetting(chatID, key, strings.Join(values, ",")); err != nil {
+			return i18n.Errorf("search.save_failed")
+		}
+	}
+	return nil
+}
+
+// validateSearch — проверки поиска, общие для команд и HTTP API
+func (b *Bot) validateSearch(chatID int64, search storage.SavedSearch) error {
+	// Основной поиск без тегов ищет по запросу по умолчанию
+	if search.Id != mainSearchID && (search.Name == "" || len(search.Tags) == 0) {
+		return invalidf("search.name_and_tags")
+	}
+	if err := b.validateSources(search.Sources); err != nil {
+		return err
+	}
+	return b.validateChannels(chatID, search.Channels)
+}
+
+func (b *Bot) validateSources(names []string) error {
+	if unknown := b.Sources.Validate(names); len(unknown) > 0 {
+		return invalidf("search.unknown_sources", strings.Join(unknown, ", "), strings.Join(b.Sources.Names(), ", "))
+	}
+	return nil
+}
+
+// setSearchSources разбирает "/sources [id] hh,superjob". Без списка источников
+// возвращает поиск без изменений, чтобы показать текущие источники.
+func (b *Bot) setSearchSources(chatID int64, args string) (*storage.SavedSearch, error) {
+	searchID := mainSearchID
+	list := strings.TrimSpace(args)
+	if first, rest, _ := strings.Cut(list, " "); first != "" && !strings.Contains(first, ",") {
+		if _, known := b.Sources[strings.ToLower(first)]; !known {
+			searchID, list = first, strings.TrimSpace(rest)
+		}
+	}
+
+	var search storage.SavedSearch
+	if searchID == mainSearchID {
+		search = mainSearch(chatID, b.Storage)
+	} else {
+		saved, err := b.Storage.GetSavedSearch(chatID, searchID)
+		if err != nil {
+			return nil, invalidf("search.not_found", searchID)
+		}
+		search = *saved
+	}
+	if list == "" {
+		return &search, nil
+	}
+
+	search.Sources = parseCSV(strings.ToLower(list))
+	if err := b.updateSearch(chatID, search); err != nil {
+		return nil, err
+	}
+	return &search, nil
+}
+
+func (b *Bot) sendSearches(chatID int64) {
+	l := b.lang(chatID)
+	var sb strings.Builder
+	sb.WriteString(l.T("searches.title") + "\n")
+	for _, s := range loadSearches(chatID, b.Storage) {
+		sb.WriteString("\n" + formatSearch(s, l) + "\n")
+	}
+	sb.WriteString("\n" + l.T("searches.footer", strings.Join(b.Sources.Names(), ",")))
+	b.SendMessage(chatID, sb.String())
+}
+
+func formatSearch(s storage.SavedSearch, l i18n.Lang) string {
+	tags := strings.Join(s.Tags, ", ")
+	if tags == "" {
+		tags = l.T("settings.not_set")
+	}
+	cities := strings.Join(s.Cities, ", ")
+	if cities == "" {
+		cities = l.T("searches.default_cities")
+	}
+	return l.T("searches.item", s.Name, s.Id, tags, cities, formatSources(searchSources(s), l))
+}
+
+func formatSources(names []string, l i18n.Lang) string {
+	titles := make([]string, 0, len(names))
+	for _, name := range names {
+		if slices.Contains(sources.Order, name) {
+			titles = append(titles, sources.Title(name, l))
+		} else {
+			titles = append(titles, name)
+		}
+	}
+	return strings.Join(titles, ", ")
+}
+
+func newSearchID() string {
+	buf := make([]byte, 3)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
@@ -0,0 +1,152 @@
+package telegram
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+	"time"
+
+	"hhruBot/internal/hh"
+	"hhruBot/internal/i18n"
+	"hhruBot/internal/logging"
+	"hhruBot/internal/storage"
+	"hhruBot/internal/tracking"
+)
+
+// Как часто перепроверяем отслеживаемые вакансии
+const trackerInterval = 6 * time.Hour
+
+// ID hh.ru — только число или ссылка на вакансию или работодателя, в том
+// числе с региональных поддоменов вроде spb.hh.ru
+var (
+	numericIDPattern   = regexp.MustCompile(`^\d+$`)
+	vacancyURLPattern  = regexp.MustCompile(`^(?:https?://)?(?:[\w-]+\.)*hh\.ru/vacancy/(\d+)(?:[/?#].*)?$`)
+	employerURLPattern = regexp.MustCompile(`^(?:https?://)?(?:[\w-]+\.)*hh\.ru/employer/(\d+)(?:[/?#].*)?$`)
+	// ID вакансий других источников: sj-123, tv-…/…, rss-…
+	foreignIDPattern = regexp.MustCompile(`^[a-z]+-\S+$`)
+)
+
+// StartVacancyTracker периодически перезапрашивает отслеживаемые вакансии всех
+// пользователей и сообщает об изменениях зарплаты, описания и статуса архива
+func StartVacancyTracker(hhClient *hh.Client, storage *storage.Storage, bot *Bot) {
+	ticker := time.NewTicker(trackerInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if storage.IsMaintenance() {
+			continue
+		}
+		users, err := storage.GetUsers()
+		if err != nil {
+			slog.Warn("трекер: не удалось получить пользователей", logging.Err(err))
+			continue
+		}
+		for _, chatID := range users {
+			checkTrackedVacancies(chatID, hhClient, storage, bot)
+		}
+	}
+}
+
+func checkTrackedVacancies(chatID int64, hhClient *hh.Client, storage *storage.Storage, bot *Bot) {
+	ids, err := storage.GetTrackedIDs(chatID)
+	if err != nil {
+		logging.Chat(chatID).Warn("трекер: не удалось получить вакансии", logging.Err(err))
+		return
+	}
+
+	lang := userLang(chatID, storage)
+	for _, id := range ids {
+		old, err := storage.GetTrackedVacancy(chatID, id)
+		if err != nil {
+			logging.Chat(chatID).Warn("трекер: нет снимка вакансии", logging.KeyVacancyID, id, logging.Err(err))
+			continue
+		}
+
+		cur, err := fetchSnapshot(hhClient, id, *old)
+		if err != nil {
+			logging.Chat(chatID).Error("трекер: не удалось загрузить вакансию", logging.KeyVacancyID, id, logging.KeyHHStatus, hh.StatusOf(err), logging.Err(err))
+			continue
+		}
+
+		changes := tracking.Diff(*old, cur, lang)
+		for _, c := range changes {
+			if err := storage.AddVacancyChange(chatID, id, c); err != nil {
+				logging.Chat(chatID).Warn("трекер: не удалось сохранить изменение", logging.KeyVacancyID, id, logging.Err(err))
+			}
+		}
+		if err := storage.TrackVacancy(chatID, cur); err != nil {
+			logging.Chat(chatID).Warn("трекер: не удалось сохранить снимок", logging.KeyVacancyID, id, logging.Err(err))
+		}
+
+		if len(changes) > 0 {
+			bot.SendMessage(chatID, formatChanges(cur, changes, lang))
+		}
+	}
+}
+
+// fetchSnapshot загружает актуальный снимок вакансии. Удалённая вакансия
+// считается архивной, остальные поля берутся из прошлого снимка.
+func fetchSnapshot(hhClient *hh.Client, id string, prev storage.TrackedVacancy) (storage.TrackedVacancy, error) {
+	v, err := hhClient.GetVacancy(id)
+	if errors.Is(err, hh.ErrNotFound) {
+		prev.Archived = true
+		prev.CheckedAt = time.Now()
+		return prev, nil
+	}
+	if err != nil {
+		return storage.TrackedVacancy{}, err
+	}
+	return tracking.Snapshot(*v, time.Now()), nil
+}
+
+// trackVacancy начинает отслеживание вакансии для пользователя
+func (b *Bot) trackVacancy(chatID int64, vacancyID string) (*storage.TrackedVacancy, error) {
+	v, err := b.HHClient.GetVacancy(vacancyID)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := tracking.Snapshot(*v, time.Now())
+	if err := b.Storage.TrackVacancy(chatID, snapshot); err != nil {
+		return nil, err
+	}
+	b.recordAction(chatID, vacancyID, actionTracked)
+	return &snapshot, nil
+}
+
+func formatChanges(v storage.TrackedVacancy, changes []storage.VacancyChange, l i18n.Lang) string {
+	var sb strings.Builder
+	sb.WriteString(l.T("tracker.changes", v.Name) + "\n")
+	fmt.Fprintf(&sb, "https://hh.ru/vacancy/%s\n", v.Id)
+	for _, c := range changes {
+		fmt.Fprintf(&sb, "%s: %s\n", tracking.KindTitle(c.Kind, l), c.Details)
+	}
+	return strings.TrimSpace(sb.String())
+}
+
+// parseVacancyID достаёт ID вакансии из числа или ссылки https://hh.ru/vacancy/123.
+// Пустой ID — ввод не распознан. Вакансии других источников запросить у hh.ru
+// нельзя, для них возвращается ошибка, а не случайные цифры из их ID.
+func parseVacancyID(input string) (string, error) {
+	input = strings.TrimSpace(input)
+	if foreignIDPattern.MatchString(input) {
+		return "", i18n.Errorf("vacancy_id.foreign", input)
+	}
+	return parseHHID(input, vacancyURLPattern), nil
+}
+
+// parseEmployerID достаёт ID работодателя из числа или ссылки https://hh.ru/employer/123
+func parseEmployerID(input string) string {
+	return parseHHID(strings.TrimSpace(input), employerURLPattern)
+}
+
+func parseHHID(input string, urlPattern *regexp.Regexp) string {
+	if numericIDPattern.MatchString(input) {
+		return input
+	}
+	if m := urlPattern.FindStringSubmatch(input); m != nil {
+		return m[1]
+	}
+	return ""
+}
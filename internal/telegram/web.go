@@ -0,0 +1,542 @@
+package telegram
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"errors"
+	"html/template"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"hhruBot/internal/i18n"
+	"hhruBot/internal/logging"
+	"hhruBot/internal/notify"
+	"hhruBot/internal/pipeline"
+	"hhruBot/internal/sources"
+	"hhruBot/internal/storage"
+)
+
+// Ошибки, которые страница входа показывает по ключу из ?err=
+var loginErrors = map[string]bool{
+	"web.login.disabled":      true,
+	"web.login.session_error": true,
+	"web.login.no_hash":       true,
+	"web.login.bad_hash":      true,
+	"web.login.expired":       true,
+	"web.login.no_id":         true,
+}
+
+const (
+	sessionCookie = "hhbot_session"
+	sessionTTL    = 7 * 24 * time.Hour
+
+	// Сколько действительна подпись виджета входа Telegram
+	loginMaxAge = 24 * time.Hour
+)
+
+//go:embed templates/*.html
+var templateFS embed.FS
+
+var webFuncs = template.FuncMap{
+	"join": strings.Join,
+	"has":  slices.Contains[[]string],
+}
+
+// Каждая страница — отдельный набор шаблонов с общим layout.html
+var webPages = map[string]*template.Template{}
+
+func init() {
+	for _, page := range []string{"login.html", "searches.html", "history.html", "bookmarks.html"} {
+		webPages[page] = template.Must(template.New("layout.html").Funcs(webFuncs).
+			ParseFS(templateFS, "templates/layout.html", "templates/"+page))
+	}
+}
+
+type webOption struct {
+	Value string
+	Title string
+}
+
+type webHistoryEntry struct {
+	storage.HistoryEntry
+	SearchName string
+	CanSave    bool // в закладки можно сохранить только вакансии hh.ru
+}
+
+type webHistoryFilter struct {
+	Search string
+	Query  string
+	Source string
+	Days   int
+}
+
+// webPage — данные для шаблонов веб-кабинета
+type webPage struct {
+	Lang    i18n.Lang
+	Loc     *time.Location // часовой пояс пользователя для дат
+	Title   string
+	Active  string
+	ChatID  int64
+	CSRF    string
+	Flash   string
+	Error   string
+	BotName string
+	AuthURL string
+
+	Searches []storage.SavedSearch
+	Sources  []webOption
+	Channels []webOption
+
+	History []webHistoryEntry
+	Filter  webHistoryFilter
+
+	Pipeline []pipelineStage
+	Statuses []webOption
+}
+
+// T — перевод для шаблонов: {{$.T "web.save"}}
+func (p *webPage) T(key string, args ...any) string {
+	return p.Lang.T(key, args...)
+}
+
+// Date — дата и время в формате языка страницы и часовом поясе пользователя
+func (p *webPage) Date(t time.Time) string {
+	if p.Loc != nil {
+		t = t.In(p.Loc)
+	}
+	return t.Format(p.Lang.T("layout.datetime"))
+}
+
+// requestLang — язык страницы до входа: по первому языку из Accept-Language
+func requestLang(r *http.Request) i18n.Lang {
+	tag, _, _ := strings.Cut(r.Header.Get("Accept-Language"), ",")
+	tag, _, _ = strings.Cut(tag, ";")
+	return i18n.FromTelegram(strings.TrimSpace(tag))
+}
+
+// WebHandler — веб-кабинет: поиски, история совпадений и закладки.
+// Вход через виджет Telegram Login, сессия хранится в Redis.
+func (b *Bot) WebHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /web/login", b.webLogin)
+	mux.HandleFunc("GET /web/auth", b.webAuth)
+	mux.HandleFunc("POST /web/logout", b.webSession(b.webLogout))
+	mux.HandleFunc("GET /web/{$}", b.webSession(b.webSearches))
+	mux.HandleFunc("POST /web/searches", b.webSession(b.webCreateSearch))
+	mux.HandleFunc("POST /web/searches/{id}", b.webSession(b.webUpdateSearch))
+	mux.HandleFunc("POST /web/searches/{id}/delete", b.webSession(b.webDeleteSearch))
+	mux.HandleFunc("GET /web/history", b.webSession(b.webHistory))
+	mux.HandleFunc("POST /web/bookmarks", b.webSession(b.webSaveBookmark))
+	mux.HandleFunc("GET /web/bookmarks", b.webSession(b.webBookmarks))
+	mux.HandleFunc("POST /web/bookmarks/{id}/status", b.webSession(b.webBookmarkStatus))
+	mux.HandleFunc("POST /web/bookmarks/{id}/note", b.webSession(b.webBookmarkNote))
+	mux.HandleFunc("POST /web/bookmarks/{id}/delete", b.webSession(b.webDeleteBookmark))
+	return mux
+}
+
+// webHandlerFunc — обработчик страницы вошедшего пользователя
+type webHandlerFunc func(w http.ResponseWriter, r *http.Request, page *webPage)
+
+// webSession пускает только вошедших пользователей и проверяет CSRF-токен у форм
+func (b *Bot) webSession(next webHandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cookie, err := r.Cookie(sessionCookie)
+		if err != nil {
+			http.Redirect(w, r, "/web/login", http.StatusSeeOther)
+			return
+		}
+		chatID, err := b.Storage.SessionOwner(cookie.Value)
+		if err != nil {
+			http.Redirect(w, r, "/web/login", http.StatusSeeOther)
+			return
+		}
+
+		page := &webPage{
+			Lang:   b.lang(chatID),
+			Loc:    b.userLocation(chatID),
+			ChatID: chatID,
+			CSRF:   csrfToken(cookie.Value),
+		}
+		page.Flash = page.flash(r, "msg")
+		page.Error = page.flash(r, "err")
+		if r.Method == http.MethodPost {
+			if !hmac.Equal([]byte(r.PostFormValue("csrf")), []byte(page.CSRF)) {
+				http.Error(w, page.T("web.bad_form"), http.StatusForbidden)
+				return
+			}
+		}
+		next(w, r, page)
+	}
+}
+
+func (b *Bot) webLogin(w http.ResponseWriter, r *http.Request) {
+	authURL := "/web/auth"
+	if b.PublicURL != "" {
+		authURL = b.PublicURL + authURL
+	}
+	page := &webPage{
+		Lang:    requestLang(r),
+		BotName: b.Api.Self.UserName,
+		AuthURL: authURL,
+	}
+	// на страницу входа приходят только ключи каталога, а не готовый текст
+	if key := r.URL.Query().Get("err"); loginErrors[key] {
+		page.Error = page.T(key)
+	}
+	page.Title = page.T("web.login")
+	b.render(w, "login.html", page)
+}
+
+// webAuth принимает редирект виджета Telegram Login и открывает сессию
+func (b *Bot) webAuth(w http.ResponseWriter, r *http.Request) {
+	chatID, err := verifyTelegramLogin(r.URL.Query(), b.Api.Token, time.Now())
+	if err != nil {
+		slog.Warn("отклонён вход в веб-кабинет", logging.Err(err))
+		key := "web.login.bad_hash"
+		var loginErr *i18n.Error
+		if errors.As(err, &loginErr) {
+			key = loginErr.Key
+		}
+		redirectWith(w, r, "/web/login", "err", key)
+		return
+	}
+	if disabled, _ := b.Storage.IsUserDisabled(chatID); disabled {
+		redirectWith(w, r, "/web/login", "err", "web.login.disabled")
+		return
+	}
+
+	sessionID := newSessionID()
+	if err := b.Storage.CreateSession(sessionID, chatID, sessionTTL); err != nil {
+		redirectWith(w, r, "/web/login", "err", "web.login.session_error")
+		return
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookie,
+		Value:    sessionID,
+		Path:     "/web/",
+		MaxAge:   int(sessionTTL.Seconds()),
+		HttpOnly: true,
+		Secure:   strings.HasPrefix(b.PublicURL, "https://"),
+		SameSite: http.SameSiteLaxMode,
+	})
+	logging.Chat(chatID).Info("вход в веб-кабинет")
+	http.Redirect(w, r, "/web/", http.StatusSeeOther)
+}
+
+func (b *Bot) webLogout(w http.ResponseWriter, r *http.Request, _ *webPage) {
+	if cookie, err := r.Cookie(sessionCookie); err == nil {
+		_ = b.Storage.DeleteSession(cookie.Value)
+	}
+	http.SetCookie(w, &http.Cookie{Name: sessionCookie, Path: "/web/", MaxAge: -1})
+	http.Redirect(w, r, "/web/login", http.StatusSeeOther)
+}
+
+func (b *Bot) webSearches(w http.ResponseWriter, _ *http.Request, page *webPage) {
+	page.Title = page.T("web.searches")
+	page.Active = "searches"
+	page.Searches = loadSearches(page.ChatID, b.Storage)
+	for _, name := range b.Sources.Names() {
+		page.Sources = append(page.Sources, webOption{Value: name, Title: sources.Title(name, page.Lang)})
+	}
+	page.Channels = []webOption{{Value: notify.Telegram, Title: page.T("web.searches.this_chat")}}
+	channels, _ := b.Storage.GetChannels(page.ChatID)
+	for _, ch := range channels {
+		page.Channels = append(page.Channels, webOption{Value: ch.Id, Title: notify.Title(ch.Kind, page.Lang) + ": " + ch.Address})
+	}
+	b.render(w, "searches.html", page)
+}
+
+func (b *Bot) webCreateSearch(w http.ResponseWriter, r *http.Request, page *webPage) {
+	var search storage.SavedSearch
+	searchForm(r, true).apply(&search)
+	created, err := b.createSearch(page.ChatID, search)
+	if err != nil {
+		page.redirect(w, r, "/web/", "err", webErrorText(page, err))
+		return
+	}
+	_ = b.Storage.AddUser(page.ChatID)
+	page.redirect(w, r, "/web/", "msg", page.T("web.searches.created", created.Name))
+}
+
+func (b *Bot) webUpdateSearch(w http.ResponseWriter, r *http.Request, page *webPage) {
+	var search *storage.SavedSearch
+	for _, s := range loadSearches(page.ChatID, b.Storage) {
+		if s.Id == r.PathValue("id") {
+			search = &s
+			break
+		}
+	}
+	if search == nil {
+		page.redirect(w, r, "/web/", "err", page.T("web.searches.not_found"))
+		return
+	}
+
+	searchForm(r, search.Id != mainSearchID).apply(search)
+	if err := b.updateSearch(page.ChatID, *search); err != nil {
+		page.redirect(w, r, "/web/", "err", webErrorText(page, err))
+		return
+	}
+	page.redirect(w, r, "/web/", "msg", page.T("web.searches.updated", search.Name))
+}
+
+func (b *Bot) webDeleteSearch(w http.ResponseWriter, r *http.Request, page *webPage) {
+	searchID := r.PathValue("id")
+	if searchID == mainSearchID {
+		page.redirect(w, r, "/web/", "err", page.T("web.searches.main_delete"))
+		return
+	}
+	if err := b.Storage.DeleteSavedSearch(page.ChatID, searchID); err != nil {
+		page.redirect(w, r, "/web/", "err", page.T("web.searches.not_found"))
+		return
+	}
+	_ = b.Storage.DeleteHistory(page.ChatID, searchID)
+	page.redirect(w, r, "/web/", "msg", page.T("web.searches.deleted"))
+}
+
+func (b *Bot) webHistory(w http.ResponseWriter, r *http.Request, page *webPage) {
+	page.Title = page.T("web.history")
+	page.Active = "history"
+	q := r.URL.Query()
+	page.Filter = webHistoryFilter{
+		Search: q.Get("search"),
+		Query:  strings.TrimSpace(q.Get("q")),
+		Source: q.Get("source"),
+	}
+	page.Filter.Days, _ = strconv.Atoi(q.Get("days"))
+
+	page.Searches = loadSearches(page.ChatID, b.Storage)
+	for _, name := range sources.Order {
+		page.Sources = append(page.Sources, webOption{Value: name, Title: sources.Title(name, page.Lang)})
+	}
+
+	query := strings.ToLower(page.Filter.Query)
+	var since time.Time
+	if page.Filter.Days > 0 {
+		since = time.Now().AddDate(0, 0, -page.Filter.Days)
+	}
+	for _, s := range page.Searches {
+		if page.Filter.Search != "" && page.Filter.Search != s.Id {
+			continue
+		}
+		history, _ := b.Storage.GetHistory(page.ChatID, s.Id)
+		for _, e := range history {
+			if e.MatchedAt.Before(since) {
+				continue
+			}
+			if page.Filter.Source != "" && historySource(e) != page.Filter.Source {
+				continue
+			}
+			if query != "" && !strings.Contains(strings.ToLower(e.Name+" "+e.Employer+" "+e.Area), query) {
+				continue
+			}
+			page.History = append(page.History, webHistoryEntry{HistoryEntry: e, SearchName: s.Name, CanSave: e.Source == ""})
+		}
+	}
+	sort.Slice(page.History, func(i, j int) bool { return page.History[i].MatchedAt.After(page.History[j].MatchedAt) })
+	b.render(w, "history.html", page)
+}
+
+// historySource — имя источника записи. В истории хранится название на
+// языке чата в момент отправки, у вакансий hh.ru оно пустое.
+func historySource(e storage.HistoryEntry) string {
+	if e.Source == "" {
+		return sources.HH
+	}
+	for _, name := range sources.Order {
+		for _, l := range i18n.Langs {
+			if sources.Title(name, l) == e.Source {
+				return name
+			}
+		}
+	}
+	return e.Source
+}
+
+func (b *Bot) webBookmarks(w http.ResponseWriter, _ *http.Request, page *webPage) {
+	page.Title = page.T("web.bookmarks")
+	page.Active = "bookmarks"
+	saved, err := b.Storage.GetSavedVacancies(page.ChatID)
+	if err != nil {
+		page.Error = page.T("web.bookmarks.load_error")
+	}
+	for _, st := range pipeline.Statuses {
+		page.Statuses = append(page.Statuses, webOption{Value: string(st), Title: st.Title(page.Lang)})
+		stage := pipelineStage{Status: string(st), Title: st.Title(page.Lang)}
+		for _, v := range saved {
+			if v.Status == string(st) {
+				stage.Vacancies = append(stage.Vacancies, v)
+			}
+		}
+		page.Pipeline = append(page.Pipeline, stage)
+	}
+	b.render(w, "bookmarks.html", page)
+}
+
+func (b *Bot) webSaveBookmark(w http.ResponseWriter, r *http.Request, page *webPage) {
+	vacancyID, err := parseVacancyID(r.PostFormValue("vacancy"))
+	if err != nil {
+		page.redirect(w, r, "/web/bookmarks", "err", page.Lang.Err(err))
+		return
+	}
+	if vacancyID == "" {
+		page.redirect(w, r, "/web/bookmarks", "err", page.T("web.bookmarks.no_vacancy"))
+		return
+	}
+	saved, err := b.saveVacancy(page.ChatID, vacancyID)
+	if err != nil {
+		page.redirect(w, r, "/web/bookmarks", "err", page.T("web.bookmarks.save_error", vacancyID))
+		return
+	}
+	page.redirect(w, r, "/web/bookmarks", "msg", page.T("web.bookmarks.saved", saved.Name))
+}
+
+func (b *Bot) webBookmarkStatus(w http.ResponseWriter, r *http.Request, page *webPage) {
+	status, ok := pipeline.Parse(r.PostFormValue("status"))
+	if !ok {
+		page.redirect(w, r, "/web/bookmarks", "err", page.T("web.bookmarks.bad_status"))
+		return
+	}
+	if _, err := b.setVacancyStatus(page.ChatID, r.PathValue("id"), status); err != nil {
+		page.redirect(w, r, "/web/bookmarks", "err", page.T("web.bookmarks.not_found"))
+		return
+	}
+	page.redirect(w, r, "/web/bookmarks", "msg", page.T("web.bookmarks.status_changed", status.Title(page.Lang)))
+}
+
+func (b *Bot) webBookmarkNote(w http.ResponseWriter, r *http.Request, page *webPage) {
+	text := strings.TrimSpace(r.PostFormValue("note"))
+	if text == "" {
+		page.redirect(w, r, "/web/bookmarks", "err", page.T("web.bookmarks.empty_note"))
+		return
+	}
+	if err := b.addVacancyNote(page.ChatID, r.PathValue("id"), text); err != nil {
+		page.redirect(w, r, "/web/bookmarks", "err", page.T("web.bookmarks.not_found"))
+		return
+	}
+	page.redirect(w, r, "/web/bookmarks", "msg", page.T("web.bookmarks.note_added"))
+}
+
+func (b *Bot) webDeleteBookmark(w http.ResponseWriter, r *http.Request, page *webPage) {
+	if err := b.Storage.DeleteSavedVacancy(page.ChatID, r.PathValue("id")); err != nil {
+		page.redirect(w, r, "/web/bookmarks", "err", page.T("web.bookmarks.delete_error"))
+		return
+	}
+	b.recordAction(page.ChatID, r.PathValue("id"), actionUnsaved)
+	page.redirect(w, r, "/web/bookmarks", "msg", page.T("web.bookmarks.deleted"))
+}
+
+func (b *Bot) render(w http.ResponseWriter, name string, page *webPage) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := webPages[name].Execute(w, page); err != nil {
+		logging.Chat(page.ChatID).Error("не удалось отрисовать страницу", "page", name, logging.Err(err))
+	}
+}
+
+// searchForm превращает форму в такой же запрос, как у HTTP API: дальше поиск
+// проходит те же проверки, что в командах и API. У основного поиска нет
+// названия и каналов.
+func searchForm(r *http.Request, withName bool) searchRequest {
+	_ = r.ParseForm()
+	tags := strings.Split(r.PostFormValue("tags"), ",")
+	cities := strings.Split(r.PostFormValue("cities"), ",")
+	srcs := r.PostForm["sources"]
+	req := searchRequest{Tags: &tags, Cities: &cities, Sources: &srcs}
+	if withName {
+		name := r.PostFormValue("name")
+		channels := r.PostForm["channels"]
+		req.Name, req.Channels = &name, &channels
+	}
+	return req
+}
+
+// webErrorText — текст ошибки поиска для пользователя; сбои хранилища пишем в лог
+func webErrorText(page *webPage, err error) string {
+	var input *inputError
+	if !errors.As(err, &input) {
+		logging.Chat(page.ChatID).Error("ошибка веб-кабинета при сохранении поиска", logging.Err(err))
+	}
+	return page.Lang.Err(err)
+}
+
+// redirectWith переходит на страницу без сессии, например на вход с ключом ошибки
+func redirectWith(w http.ResponseWriter, r *http.Request, path, key, value string) {
+	http.Redirect(w, r, path+"?"+url.Values{key: {value}}.Encode(), http.StatusSeeOther)
+}
+
+// redirect переходит на страницу кабинета с сообщением. Сообщение подписано
+// CSRF-токеном сессии: чужая ссылка с ?msg=… не покажет пользователю свой текст.
+func (p *webPage) redirect(w http.ResponseWriter, r *http.Request, path, key, value string) {
+	query := url.Values{key: {value}, key + "_sig": {flashSignature(p.CSRF, key, value)}}
+	http.Redirect(w, r, path+"?"+query.Encode(), http.StatusSeeOther)
+}
+
+// flash возвращает сообщение из запроса, если его подпись совпала
+func (p *webPage) flash(r *http.Request, key string) string {
+	q := r.URL.Query()
+	value := q.Get(key)
+	if value == "" || !hmac.Equal([]byte(q.Get(key+"_sig")), []byte(flashSignature(p.CSRF, key, value))) {
+		return ""
+	}
+	return value
+}
+
+func flashSignature(secret, key, value string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(key + "=" + value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// verifyTelegramLogin проверяет подпись виджета Telegram Login:
+// HMAC-SHA256 от отсортированных полей "ключ=значение" с ключом SHA-256(токен бота)
+func verifyTelegramLogin(values url.Values, botToken string, now time.Time) (int64, error) {
+	hash := values.Get("hash")
+	if hash == "" {
+		return 0, i18n.Errorf("web.login.no_hash")
+	}
+
+	var fields []string
+	for key := range values {
+		if key != "hash" {
+			fields = append(fields, key+"="+values.Get(key))
+		}
+	}
+	sort.Strings(fields)
+
+	secret := sha256.Sum256([]byte(botToken))
+	mac := hmac.New(sha256.New, secret[:])
+	mac.Write([]byte(strings.Join(fields, "\n")))
+	if !hmac.Equal([]byte(hex.EncodeToString(mac.Sum(nil))), []byte(hash)) {
+		return 0, i18n.Errorf("web.login.bad_hash")
+	}
+
+	authDate, err := strconv.ParseInt(values.Get("auth_date"), 10, 64)
+	if err != nil || now.Sub(time.Unix(authDate, 0)) > loginMaxAge {
+		return 0, i18n.Errorf("web.login.expired")
+	}
+	chatID, err := strconv.ParseInt(values.Get("id"), 10, 64)
+	if err != nil {
+		return 0, i18n.Errorf("web.login.no_id")
+	}
+	return chatID, nil
+}
+
+// csrfToken выводится из ID сессии: без cookie сессии его не подобрать
+func csrfToken(sessionID string) string {
+	sum := sha256.Sum256([]byte("csrf:" + sessionID))
+	return hex.EncodeToString(sum[:16])
+}
+
+func newSessionID() string {
+	buf := make([]byte, 24)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
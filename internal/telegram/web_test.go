@@ -0,0 +1,134 @@
+package telegram
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"hhruBot/internal/i18n"
+)
+
+const testBotToken = "123456:test-token"
+
+// signLogin подписывает поля так же, как виджет Telegram Login
+func signLogin(values url.Values, token string) url.Values {
+	var fields []string
+	for key := range values {
+		fields = append(fields, key+"="+values.Get(key))
+	}
+	sort.Strings(fields)
+	secret := sha256.Sum256([]byte(token))
+	mac := hmac.New(sha256.New, secret[:])
+	mac.Write([]byte(strings.Join(fields, "\n")))
+
+	signed := url.Values{}
+	for key, v := range values {
+		signed[key] = v
+	}
+	signed.Set("hash", hex.EncodeToString(mac.Sum(nil)))
+	return signed
+}
+
+func TestVerifyTelegramLogin(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	fields := func(authDate time.Time) url.Values {
+		return url.Values{
+			"id":         {"42"},
+			"first_name": {"Иван"},
+			"username":   {"ivan"},
+			"auth_date":  {strconv.FormatInt(authDate.Unix(), 10)},
+		}
+	}
+	tampered := signLogin(fields(now), testBotToken)
+	tampered.Set("id", "43")
+	noID := fields(now)
+	noID.Del("id")
+	noHash := fields(now)
+
+	tests := []struct {
+		name    string
+		values  url.Values
+		wantID  int64
+		wantErr string
+	}{
+		{"верная подпись", signLogin(fields(now.Add(-time.Hour)), testBotToken), 42, ""},
+		{"подменённое поле", tampered, 0, "web.login.bad_hash"},
+		{"чужой токен", signLogin(fields(now), "654321:other-token"), 0, "web.login.bad_hash"},
+		{"устаревшая подпись", signLogin(fields(now.Add(-loginMaxAge-time.Minute)), testBotToken), 0, "web.login.expired"},
+		{"нет подписи", noHash, 0, "web.login.no_hash"},
+		{"нет ID", signLogin(noID, testBotToken), 0, "web.login.no_id"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := verifyTelegramLogin(tt.values, testBotToken, now)
+			if tt.wantErr == "" {
+				if err != nil || id != tt.wantID {
+					t.Fatalf("verifyTelegramLogin() = %d, %v; want %d, nil", id, err, tt.wantID)
+				}
+				return
+			}
+			var loginErr *i18n.Error
+			if !errors.As(err, &loginErr) || loginErr.Key != tt.wantErr {
+				t.Fatalf("verifyTelegramLogin() error = %v; want %s", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCSRFToken(t *testing.T) {
+	tests := []struct {
+		name      string
+		session   string
+		other     string
+		wantEqual bool
+	}{
+		{"та же сессия", "session-a", "session-a", true},
+		{"другая сессия", "session-a", "session-b", false},
+		{"подменённый символ", "session-a", "session-A", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := csrfToken(tt.session)
+			if len(token) != 32 {
+				t.Fatalf("len(csrfToken) = %d; want 32", len(token))
+			}
+			if got := token == csrfToken(tt.other); got != tt.wantEqual {
+				t.Fatalf("csrfToken(%q) == csrfToken(%q): %v; want %v", tt.session, tt.other, got, tt.wantEqual)
+			}
+		})
+	}
+}
+
+func TestFlashSignature(t *testing.T) {
+	page := &webPage{CSRF: csrfToken("session-a")}
+	signed := url.Values{"msg": {"Поиск сохранён"}, "msg_sig": {flashSignature(page.CSRF, "msg", "Поиск сохранён")}}
+	forged := url.Values{"msg": {"Ваш аккаунт заблокирован"}, "msg_sig": {signed.Get("msg_sig")}}
+	otherSession := url.Values{"msg": {"Поиск сохранён"}, "msg_sig": {flashSignature(csrfToken("session-b"), "msg", "Поиск сохранён")}}
+
+	tests := []struct {
+		name  string
+		query url.Values
+		want  string
+	}{
+		{"подписанное сообщение", signed, "Поиск сохранён"},
+		{"без подписи", url.Values{"msg": {"Поиск сохранён"}}, ""},
+		{"подменённый текст", forged, ""},
+		{"подпись другой сессии", otherSession, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/web/?"+tt.query.Encode(), nil)
+			if got := page.flash(r, "msg"); got != tt.want {
+				t.Fatalf("flash() = %q; want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+// Отслеживание изменений вакансий, за которыми следит пользователь
+package tracking
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"hhruBot/internal/dedup"
+	"hhruBot/internal/hh"
+	"hhruBot/internal/i18n"
+	"hhruBot/internal/storage"
+)
+
+// Виды изменений в истории вакансии
+const (
+	KindSalary      = "salary"
+	KindDescription = "description"
+	KindArchived    = "archived"
+	KindReopened    = "reopened"
+)
+
+// Ниже этого сходства описание считается существенно изменённым
+const descriptionEditThreshold = 0.85
+
+var htmlTag = regexp.MustCompile(`<[^>]*>`)
+
+// Snapshot строит снимок вакансии для хранения
+func Snapshot(v hh.Vacancy, at time.Time) storage.TrackedVacancy {
+	t := storage.TrackedVacancy{
+		Id:          v.Id,
+		Name:        v.Name,
+		Description: plainText(v.Description),
+		Archived:    v.Archived,
+		CheckedAt:   at,
+	}
+	if s := v.Salary; s != nil {
+		if s.From != nil {
+			t.SalaryFrom = *s.From
+		}
+		if s.To != nil {
+			t.SalaryTo = *s.To
+		}
+		t.SalaryCurrency = s.Currency
+	}
+	return t
+}
+
+// Diff сравнивает два снимка и возвращает изменения, о которых стоит сообщить.
+// Описания изменений пишутся на языке lang и так хранятся в истории.
+func Diff(old, cur storage.TrackedVacancy, lang i18n.Lang) []storage.VacancyChange {
+	var changes []storage.VacancyChange
+	add := func(kind, details string) {
+		changes = append(changes, storage.VacancyChange{At: cur.CheckedAt, Kind: kind, Details: details})
+	}
+
+	if old.SalaryFrom != cur.SalaryFrom || old.SalaryTo != cur.SalaryTo || old.SalaryCurrency != cur.SalaryCurrency {
+		add(KindSalary, fmt.Sprintf("%s → %s", FormatSalary(old, lang), FormatSalary(cur, lang)))
+	}
+
+	if old.Description != cur.Description && dedup.Similarity(old.Description, cur.Description) < descriptionEditThreshold {
+		add(KindDescription, lang.T("tracking.description_changed"))
+	}
+
+	switch {
+	case !old.Archived && cur.Archived:
+		add(KindArchived, lang.T("tracking.archived"))
+	case old.Archived && !cur.Archived:
+		add(KindReopened, lang.T("tracking.reopened"))
+	}
+
+	return changes
+}
+
+// FormatSalary выводит вилку снимка так, как её указал работодатель
+func FormatSalary(t storage.TrackedVacancy, lang i18n.Lang) string {
+	switch {
+	case t.SalaryFrom > 0 && t.SalaryTo > 0:
+		return fmt.Sprintf("%d–%d %s", t.SalaryFrom, t.SalaryTo, t.SalaryCurrency)
+	case t.SalaryFrom > 0:
+		return lang.T("salary.from", fmt.Sprintf("%d %s", t.SalaryFrom, t.SalaryCurrency))
+	case t.SalaryTo > 0:
+		return lang.T("salary.to", fmt.Sprintf("%d %s", t.SalaryTo, t.SalaryCurrency))
+	}
+	return lang.T("salary.none")
+}
+
+// KindTitle — человекочитаемое название вида изменения
+func KindTitle(kind string, lang i18n.Lang) string {
+	switch kind {
+	case KindSalary, KindDescription, KindArchived, KindReopened:
+		return lang.T("tracking.kind." + kind)
+	}
+	return kind
+}
+
+func plainText(html string) string {
+	return strings.Join(strings.Fields(htmlTag.ReplaceAllString(html, " ")), " ")
+}